@@ -18,6 +18,7 @@ func main() {
 				rules.NewTerraformModuleSourceVersion(),
 				rules.NewTerraformVarsObjectKeysNamingConventions(),
 				rules.NewTerraformRequiredVariables(),
+				rules.NewTerraformSensitiveOutputs(),
 			},
 		},
 	})
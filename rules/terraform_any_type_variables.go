@@ -2,10 +2,15 @@ package rules
 
 import (
 	"fmt"
-	"slices"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
 
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
 )
 
 // TerraformAnyTypeVariables checks whether variables have a type declared
@@ -14,7 +19,11 @@ type TerraformAnyTypeVariables struct {
 }
 
 type terraformAnyTypeVariablesConfig struct {
-	IgnoreVars []string `hclext:"ignore_vars,optional"`
+	IgnoreVars        []string `hclext:"ignore_vars,optional"`
+	AllowedPaths      []string `hclext:"allowed_paths,optional"`
+	DescriptionMarker string   `hclext:"description_marker,optional"`
+	FixInferredType   bool     `hclext:"fix_inferred_type,optional"`
+	RequireType       bool     `hclext:"require_type,optional"`
 }
 
 // NewTerraformAnyTypeVariables returns a new rule
@@ -44,7 +53,10 @@ func (r *TerraformAnyTypeVariables) Link() string {
 
 // Check checks whether variables have type
 func (r *TerraformAnyTypeVariables) Check(runner tflint.Runner) error {
-	config := &terraformAnyTypeVariablesConfig{}
+	// Load rule configuration, defaulting to the '@allow-any' description marker
+	config := &terraformAnyTypeVariablesConfig{
+		DescriptionMarker: "@allow-any",
+	}
 
 	if err := runner.DecodeRuleConfig(r.Name(), config); err != nil {
 		return err
@@ -60,6 +72,12 @@ func (r *TerraformAnyTypeVariables) Check(runner tflint.Runner) error {
 						{
 							Name: "type",
 						},
+						{
+							Name: "default",
+						},
+						{
+							Name: "description",
+						},
 					},
 				},
 			},
@@ -70,27 +88,466 @@ func (r *TerraformAnyTypeVariables) Check(runner tflint.Runner) error {
 	}
 
 	for _, variable := range variables.Blocks {
-		// Skip this check if the variable name match any of the string in ignore_vars.
-		if slices.Contains(config.IgnoreVars, variable.Labels[0]) {
+		// Skip this check if the variable name match any of the patterns in ignore_vars,
+		// or if the variable description contains the marker.
+		ignored, err := matchAnyPattern(config.IgnoreVars, variable.Labels[0])
+		if err != nil {
+			return err
+		}
+		if ignored {
+			continue
+		}
+		marked, err := config.hasDescriptionMarker(runner, variable)
+		if err != nil {
+			return err
+		}
+		if marked {
 			continue
 		}
 
 		typeAttr, typeExist := variable.Body.Attributes["type"]
 		if !typeExist {
+			// A variable without type accepts any value, just like 'any'.
+			if config.RequireType {
+				if err := r.emitUntypedIssue(runner, variable, config); err != nil {
+					return err
+				}
+			}
 			continue
 		}
 
-		for _, typeExpr := range typeAttr.Expr.Variables() {
-			if typeExpr.RootName() == "any" {
-				if err := runner.EmitIssue(r,
-					fmt.Sprintf("variable '%s' has 'any' type declared", variable.Labels[0]),
-					typeExpr.SourceRange(),
-				); err != nil {
+		// Convert hcl.Expression to hclsyntax.Expression
+		syntaxExpr, ok := typeAttr.Expr.(hclsyntax.Expression)
+		if !ok {
+			// Attribute paths are not available in JSON syntax, so report against the variable itself.
+			allowed, err := matchAnyPattern(config.AllowedPaths, variable.Labels[0])
+			if err != nil {
+				return err
+			}
+			for _, typeExpr := range typeAttr.Expr.Variables() {
+				if typeExpr.RootName() == "any" && !allowed {
+					if err := runner.EmitIssue(r,
+						fmt.Sprintf("variable '%s' has 'any' type declared%s", variable.Labels[0], config.variableExemption()),
+						typeExpr.SourceRange(),
+					); err != nil {
+						return err
+					}
+				}
+			}
+			continue
+		}
+
+		err = walkTypeExpr(syntaxExpr, variable.Labels[0], func(path string, typeExpr *hclsyntax.ScopeTraversalExpr) error {
+			if typeExpr.Traversal.RootName() != "any" {
+				return nil
+			}
+			allowed, err := matchAnyPattern(config.AllowedPaths, path)
+			if err != nil || allowed {
+				return err
+			}
+
+			if path != variable.Labels[0] {
+				return runner.EmitIssue(r,
+					fmt.Sprintf("variable '%s' has 'any' type declared at '%s'; exempt it with allowed_paths", variable.Labels[0], path),
+					typeExpr.SrcRange,
+				)
+			}
+
+			// 'any' as the element type of e.g. 'map(any)' keeps the path of the variable, but the type inferred
+			// from the default describes the whole type, so it cannot replace the element type.
+			if unwrapParenthesesExpr(syntaxExpr) != typeExpr {
+				return runner.EmitIssue(r,
+					fmt.Sprintf("variable '%s' has 'any' type declared%s", variable.Labels[0], config.variableExemption()),
+					typeExpr.SrcRange,
+				)
+			}
+
+			// Suggest a concrete type if the variable type is entirely 'any' and it can be inferred from the default.
+			inferred, err := inferVariableType(runner, variable)
+			if err != nil {
+				return err
+			}
+			if inferred == "" {
+				return runner.EmitIssue(r,
+					fmt.Sprintf("variable '%s' has 'any' type declared%s", variable.Labels[0], config.variableExemption()),
+					typeExpr.SrcRange,
+				)
+			}
+
+			message := fmt.Sprintf("variable '%s' has 'any' type declared, consider '%s' inferred from its default%s", variable.Labels[0], inferred, config.variableExemption())
+			if !config.FixInferredType {
+				return runner.EmitIssue(r, message, typeExpr.SrcRange)
+			}
+			return runner.EmitIssueWithFix(r, message, typeExpr.SrcRange, func(f tflint.Fixer) error {
+				return f.ReplaceText(typeExpr.SrcRange, inferred)
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walkTypeExpr calls fn for every type keyword (e.g. `string`, `any`) within a type expression,
+// together with the attribute path it is declared at. Like checkNestedObjectFields, the path
+// only grows with object attributes, e.g. `map(object({ settings = any }))` in variable `x`
+// yields `x.settings`.
+func walkTypeExpr(expr hclsyntax.Expression, path string, fn func(path string, typeExpr *hclsyntax.ScopeTraversalExpr) error) error {
+	switch expr := expr.(type) {
+	case *hclsyntax.ScopeTraversalExpr:
+		return fn(path, expr)
+
+	case *hclsyntax.FunctionCallExpr:
+		if objExpr, ok := unwrapToObjectConsExpr(expr); ok {
+			for _, item := range objExpr.Items {
+				// Values under keys that cannot be resolved are still walked, with the path of the object
+				fieldPath := path
+				if fieldName := typeAttributeName(item.KeyExpr); fieldName != "" {
+					fieldPath = fmt.Sprintf("%s.%s", path, fieldName)
+				}
+
+				if err := walkTypeExpr(item.ValueExpr, fieldPath, fn); err != nil {
 					return err
 				}
 			}
+			return nil
+		}
+
+		// map(...), list(...), set(...), tuple([...]) and optional(...) keep the same path.
+		// Default values of optional(type, default) are not types, so only the first argument is walked.
+		args := expr.Args
+		if expr.Name == "optional" && len(args) > 1 {
+			args = args[:1]
+		}
+		for _, arg := range args {
+			if err := walkTypeExpr(arg, path, fn); err != nil {
+				return err
+			}
 		}
+
+	case *hclsyntax.TupleConsExpr:
+		for _, elem := range expr.Exprs {
+			if err := walkTypeExpr(elem, path, fn); err != nil {
+				return err
+			}
+		}
+
+	case *hclsyntax.ParenthesesExpr:
+		return walkTypeExpr(expr.Expression, path, fn)
 	}
 
 	return nil
 }
+
+// typeAttributeName returns the attribute name of an object type key expression. Unlike
+// extractKeyName, it also supports quoted attribute names, e.g. `"settings" = any`.
+func typeAttributeName(keyExpr hclsyntax.Expression) string {
+	if name := extractKeyName(keyExpr); name != "" {
+		return name
+	}
+
+	if wrapped, ok := keyExpr.(*hclsyntax.ObjectConsKeyExpr); ok {
+		keyExpr = wrapped.Wrapped
+	}
+	// Quoted names without interpolation are templates of a single literal
+	if template, ok := keyExpr.(*hclsyntax.TemplateExpr); ok && template.IsStringLiteral() {
+		if val, diags := template.Value(nil); !diags.HasErrors() && val.Type() == cty.String {
+			return val.AsString()
+		}
+	}
+
+	return ""
+}
+
+// variableExemption describes how to exempt a whole variable from this rule, to be appended to issue messages.
+func (config *terraformAnyTypeVariablesConfig) variableExemption() string {
+	if config.DescriptionMarker == "" {
+		return "; exempt it with ignore_vars"
+	}
+	return fmt.Sprintf("; exempt it with ignore_vars or '%s' in its description", config.DescriptionMarker)
+}
+
+// hasDescriptionMarker returns whether the variable description contains the configured marker.
+func (config *terraformAnyTypeVariablesConfig) hasDescriptionMarker(runner tflint.Runner, variable *hclext.Block) (bool, error) {
+	descriptionAttr, descriptionExist := variable.Body.Attributes["description"]
+	if config.DescriptionMarker == "" || !descriptionExist {
+		return false, nil
+	}
+
+	var marked bool
+	err := runner.EvaluateExpr(descriptionAttr.Expr, func(description string) error {
+		marked = strings.Contains(description, config.DescriptionMarker)
+		return nil
+	}, nil)
+
+	return marked, err
+}
+
+// matchAnyPattern returns whether the name matches any of the patterns.
+// A pattern is either a glob (e.g. `extra_*`), or a regular expression wrapped in slashes (e.g. `/_overrides$/`).
+func matchAnyPattern(patterns []string, name string) (bool, error) {
+	for _, pattern := range patterns {
+		var matched bool
+		var err error
+		if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+			matched, err = regexp.MatchString(pattern[1:len(pattern)-1], name)
+		} else {
+			matched, err = path.Match(pattern, name)
+		}
+		if err != nil {
+			return false, fmt.Errorf("`%s` is invalid pattern: %w", pattern, err)
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// emitUntypedIssue reports a variable without type, suggesting a type inferred from its default if possible.
+func (r *TerraformAnyTypeVariables) emitUntypedIssue(runner tflint.Runner, variable *hclext.Block, config *terraformAnyTypeVariablesConfig) error {
+	inferred, err := inferVariableType(runner, variable)
+	if err != nil {
+		return err
+	}
+	if inferred == "" {
+		return runner.EmitIssue(r,
+			fmt.Sprintf("variable '%s' has no type declared%s", variable.Labels[0], config.variableExemption()),
+			variable.DefRange,
+		)
+	}
+
+	message := fmt.Sprintf("variable '%s' has no type declared, consider '%s' inferred from its default%s", variable.Labels[0], inferred, config.variableExemption())
+	if !config.FixInferredType {
+		return runner.EmitIssue(r, message, variable.DefRange)
+	}
+	return runner.EmitIssueWithFix(r, message, variable.DefRange, func(f tflint.Fixer) error {
+		file, err := runner.GetFile(variable.DefRange.Filename)
+		if err != nil {
+			return err
+		}
+
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			return tflint.ErrFixNotSupported
+		}
+
+		// Insert the type as the first attribute, right after the opening brace of the block.
+		// Blocks written on a single line are not supported.
+		for _, block := range body.Blocks {
+			if block.Type != "variable" || block.DefRange() != variable.DefRange {
+				continue
+			}
+			rest := string(file.Bytes[block.OpenBraceRange.End.Byte:])
+			if !strings.HasPrefix(strings.TrimLeft(rest, " \t\r"), "\n") {
+				return tflint.ErrFixNotSupported
+			}
+
+			return f.InsertTextAfter(block.OpenBraceRange, fmt.Sprintf("\n  type = %s", inferred))
+		}
+
+		return tflint.ErrFixNotSupported
+	})
+}
+
+// inferVariableType returns a type expression inferred from the default value of the variable,
+// or an empty string if the variable has no default or its type cannot be inferred.
+func inferVariableType(runner tflint.Runner, variable *hclext.Block) (string, error) {
+	defaultAttr, defaultExist := variable.Body.Attributes["default"]
+	if !defaultExist {
+		return "", nil
+	}
+
+	var inferred *inferredType
+	err := runner.EvaluateExpr(defaultAttr.Expr, func(val cty.Value) error {
+		inferred = inferType(val)
+		return nil
+	}, nil)
+	if err != nil || inferred == nil {
+		return "", err
+	}
+
+	return inferred.String(), nil
+}
+
+// inferredType is a type inferred from a value, which can be rendered as a type expression.
+// Objects whose attributes all share the same type are rendered as maps.
+type inferredType struct {
+	Name     string                   // "string", "number", "bool", "list", "set", "object" or "tuple"
+	Elem     *inferredType            // element type of lists and sets
+	Attrs    map[string]*inferredType // attribute types of objects
+	Optional map[string]bool          // attributes absent in some of the unified objects
+	Elems    []*inferredType          // element types of tuples
+}
+
+// inferType infers the type of a value. It returns nil if the value is unknown, null,
+// or contains empty collections, whose element types cannot be inferred.
+func inferType(val cty.Value) *inferredType {
+	if !val.IsWhollyKnown() || val.IsNull() {
+		return nil
+	}
+
+	ty := val.Type()
+	switch {
+	case ty == cty.String:
+		return &inferredType{Name: "string"}
+	case ty == cty.Number:
+		return &inferredType{Name: "number"}
+	case ty == cty.Bool:
+		return &inferredType{Name: "bool"}
+
+	case ty.IsMapType() || ty.IsObjectType():
+		if val.LengthInt() == 0 {
+			return nil
+		}
+		inferred := &inferredType{Name: "object", Attrs: map[string]*inferredType{}, Optional: map[string]bool{}}
+		for it := val.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			attr := inferType(v)
+			if attr == nil {
+				return nil
+			}
+			inferred.Attrs[k.AsString()] = attr
+		}
+		return inferred
+
+	case ty.IsListType() || ty.IsSetType() || ty.IsTupleType():
+		if val.LengthInt() == 0 {
+			return nil
+		}
+		var elems []*inferredType
+		for it := val.ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			elem := inferType(v)
+			if elem == nil {
+				return nil
+			}
+			elems = append(elems, elem)
+		}
+		elem, ok := unifyInferredTypes(elems)
+		if !ok {
+			return &inferredType{Name: "tuple", Elems: elems}
+		}
+		if ty.IsSetType() {
+			return &inferredType{Name: "set", Elem: elem}
+		}
+		return &inferredType{Name: "list", Elem: elem}
+	}
+
+	return nil
+}
+
+// unifyInferredTypes returns a single type which all the given types conform to.
+func unifyInferredTypes(types []*inferredType) (*inferredType, bool) {
+	unified := types[0]
+	for _, ty := range types[1:] {
+		var ok bool
+		if unified, ok = unifyInferredType(unified, ty); !ok {
+			return nil, false
+		}
+	}
+	return unified, true
+}
+
+// unifyInferredType returns a type which both types conform to.
+// Attributes absent in one of two objects become optional.
+func unifyInferredType(a, b *inferredType) (*inferredType, bool) {
+	if a.Name != b.Name {
+		return nil, false
+	}
+
+	switch a.Name {
+	case "list", "set":
+		elem, ok := unifyInferredType(a.Elem, b.Elem)
+		if !ok {
+			return nil, false
+		}
+		return &inferredType{Name: a.Name, Elem: elem}, true
+
+	case "tuple":
+		if len(a.Elems) != len(b.Elems) {
+			return nil, false
+		}
+		elems := make([]*inferredType, len(a.Elems))
+		for i := range a.Elems {
+			var ok bool
+			if elems[i], ok = unifyInferredType(a.Elems[i], b.Elems[i]); !ok {
+				return nil, false
+			}
+		}
+		return &inferredType{Name: "tuple", Elems: elems}, true
+
+	case "object":
+		unified := &inferredType{Name: "object", Attrs: map[string]*inferredType{}, Optional: map[string]bool{}}
+		for name, attr := range a.Attrs {
+			other, exists := b.Attrs[name]
+			if !exists {
+				unified.Attrs[name] = attr
+				unified.Optional[name] = true
+				continue
+			}
+			attr, ok := unifyInferredType(attr, other)
+			if !ok {
+				return nil, false
+			}
+			unified.Attrs[name] = attr
+			unified.Optional[name] = a.Optional[name] || b.Optional[name]
+		}
+		for name, attr := range b.Attrs {
+			if _, exists := a.Attrs[name]; !exists {
+				unified.Attrs[name] = attr
+				unified.Optional[name] = true
+			}
+		}
+		return unified, true
+	}
+
+	return a, true
+}
+
+// String renders the type as a type expression, e.g. `map(object({ name = string, size = optional(number) }))`.
+func (t *inferredType) String() string {
+	switch t.Name {
+	case "list", "set":
+		return fmt.Sprintf("%s(%s)", t.Name, t.Elem)
+
+	case "tuple":
+		elems := make([]string, len(t.Elems))
+		for i, elem := range t.Elems {
+			elems[i] = elem.String()
+		}
+		return fmt.Sprintf("tuple([%s])", strings.Join(elems, ", "))
+
+	case "object":
+		names := make([]string, 0, len(t.Attrs))
+		attrs := make([]*inferredType, 0, len(t.Attrs))
+		for name, attr := range t.Attrs {
+			names = append(names, name)
+			attrs = append(attrs, attr)
+		}
+
+		// Attributes sharing the same type are likely arbitrary keys, e.g. { web = {...}, db = {...} }.
+		if elem, ok := unifyInferredTypes(attrs); ok {
+			return fmt.Sprintf("map(%s)", elem)
+		}
+
+		sort.Strings(names)
+		fields := make([]string, len(names))
+		for i, name := range names {
+			key := name
+			if !hclsyntax.ValidIdentifier(name) {
+				key = fmt.Sprintf("%q", name)
+			}
+			if t.Optional[name] {
+				fields[i] = fmt.Sprintf("%s = optional(%s)", key, t.Attrs[name])
+			} else {
+				fields[i] = fmt.Sprintf("%s = %s", key, t.Attrs[name])
+			}
+		}
+		return fmt.Sprintf("object({ %s })", strings.Join(fields, ", "))
+	}
+
+	return t.Name
+}
@@ -13,6 +13,7 @@ func Test_TerraformAnyTypeVariables(t *testing.T) {
 		Content  string
 		Config   string
 		Expected helper.Issues
+		Fixed    string
 	}{
 		{
 			Name: "simple variable without 'any' type",
@@ -58,7 +59,7 @@ variable "my_var" {
 			Expected: helper.Issues{
 				{
 					Rule:    NewTerraformAnyTypeVariables(),
-					Message: "variable 'my_var' has 'any' type declared",
+					Message: "variable 'my_var' has 'any' type declared; exempt it with ignore_vars or '@allow-any' in its description",
 					Range: hcl.Range{
 						Filename: "main.tf",
 						Start:    hcl.Pos{Line: 3, Column: 10},
@@ -78,7 +79,7 @@ variable "my_var" {
 			Expected: helper.Issues{
 				{
 					Rule:    NewTerraformAnyTypeVariables(),
-					Message: "variable 'my_var' has 'any' type declared",
+					Message: "variable 'my_var' has 'any' type declared at 'my_var.my_key'; exempt it with allowed_paths",
 					Range: hcl.Range{
 						Filename: "main.tf",
 						Start:    hcl.Pos{Line: 4, Column: 14},
@@ -87,6 +88,422 @@ variable "my_var" {
 				},
 			},
 		},
+		{
+			Name: "complex variable with 'any' type under a quoted key",
+			Content: `
+variable "my_var" {
+  type = object({
+    "settings" = any
+  })
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformAnyTypeVariables(),
+					Message: "variable 'my_var' has 'any' type declared at 'my_var.settings'; exempt it with allowed_paths",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 18},
+						End:      hcl.Pos{Line: 4, Column: 21},
+					},
+				},
+			},
+		},
+		{
+			Name: "deeply nested variable with 'any' types",
+			Content: `
+variable "my_var" {
+  type = map(object({
+    name     = string
+    settings = any
+    nested = list(object({
+      tags  = optional(any, {})
+      items = tuple([string, any])
+    }))
+  }))
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformAnyTypeVariables(),
+					Message: "variable 'my_var' has 'any' type declared at 'my_var.settings'; exempt it with allowed_paths",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 16},
+						End:      hcl.Pos{Line: 5, Column: 19},
+					},
+				},
+				{
+					Rule:    NewTerraformAnyTypeVariables(),
+					Message: "variable 'my_var' has 'any' type declared at 'my_var.nested.tags'; exempt it with allowed_paths",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 24},
+						End:      hcl.Pos{Line: 7, Column: 27},
+					},
+				},
+				{
+					Rule:    NewTerraformAnyTypeVariables(),
+					Message: "variable 'my_var' has 'any' type declared at 'my_var.nested.items'; exempt it with allowed_paths",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 30},
+						End:      hcl.Pos{Line: 8, Column: 33},
+					},
+				},
+			},
+		},
+		{
+			Name: "nested variable with 'any' type at allowed paths",
+			Content: `
+variable "my_var" {
+  type = map(object({
+    name     = string
+    settings = any
+    extra    = any
+  }))
+}
+
+variable "my_passthrough" {
+  type = any
+}`,
+			Config: `
+rule "terraform_any_type_variables" {
+  enabled = true
+
+  allowed_paths = ["my_var.settings", "my_passthrough"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformAnyTypeVariables(),
+					Message: "variable 'my_var' has 'any' type declared at 'my_var.extra'; exempt it with allowed_paths",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 16},
+						End:      hcl.Pos{Line: 6, Column: 19},
+					},
+				},
+			},
+		},
+		{
+			Name: "variable with 'any' type and a default",
+			Content: `
+variable "my_var" {
+  type = any
+  default = {
+    web = {
+      name = "web"
+      size = 2
+    }
+    db = {
+      name = "db"
+    }
+  }
+}
+
+variable "my_list" {
+  type    = any
+  default = [["a"], ["b", "c"]]
+}
+
+variable "my_tuple" {
+  type    = any
+  default = ["a", 1]
+}
+
+variable "my_empty" {
+  type    = any
+  default = {}
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformAnyTypeVariables(),
+					Message: "variable 'my_var' has 'any' type declared, consider 'map(object({ name = string, size = optional(number) }))' inferred from its default; exempt it with ignore_vars or '@allow-any' in its description",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 3, Column: 13},
+					},
+				},
+				{
+					Rule:    NewTerraformAnyTypeVariables(),
+					Message: "variable 'my_list' has 'any' type declared, consider 'list(list(string))' inferred from its default; exempt it with ignore_vars or '@allow-any' in its description",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 16, Column: 13},
+						End:      hcl.Pos{Line: 16, Column: 16},
+					},
+				},
+				{
+					Rule:    NewTerraformAnyTypeVariables(),
+					Message: "variable 'my_tuple' has 'any' type declared, consider 'tuple([string, number])' inferred from its default; exempt it with ignore_vars or '@allow-any' in its description",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 21, Column: 13},
+						End:      hcl.Pos{Line: 21, Column: 16},
+					},
+				},
+				{
+					Rule:    NewTerraformAnyTypeVariables(),
+					Message: "variable 'my_empty' has 'any' type declared; exempt it with ignore_vars or '@allow-any' in its description",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 26, Column: 13},
+						End:      hcl.Pos{Line: 26, Column: 16},
+					},
+				},
+			},
+		},
+		{
+			Name: "variable with 'any' type and a default, fix inferred type",
+			Content: `
+variable "my_var" {
+  type = any
+  default = {
+    name    = "web"
+    size    = 2
+    enabled = true
+  }
+}`,
+			Config: `
+rule "terraform_any_type_variables" {
+  enabled = true
+
+  fix_inferred_type = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformAnyTypeVariables(),
+					Message: "variable 'my_var' has 'any' type declared, consider 'object({ enabled = bool, name = string, size = number })' inferred from its default; exempt it with ignore_vars or '@allow-any' in its description",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 3, Column: 13},
+					},
+				},
+			},
+			Fixed: `
+variable "my_var" {
+  type = object({ enabled = bool, name = string, size = number })
+  default = {
+    name    = "web"
+    size    = 2
+    enabled = true
+  }
+}`,
+		},
+		{
+			Name: "variables with 'any' element types and a default, fix inferred type",
+			Content: `
+variable "my_map" {
+  type    = map(any)
+  default = { a = 1 }
+}
+
+variable "my_list" {
+  type    = list(any)
+  default = ["a", "b"]
+}`,
+			Config: `
+rule "terraform_any_type_variables" {
+  enabled = true
+
+  fix_inferred_type = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformAnyTypeVariables(),
+					Message: "variable 'my_map' has 'any' type declared; exempt it with ignore_vars or '@allow-any' in its description",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 17},
+						End:      hcl.Pos{Line: 3, Column: 20},
+					},
+				},
+				{
+					Rule:    NewTerraformAnyTypeVariables(),
+					Message: "variable 'my_list' has 'any' type declared; exempt it with ignore_vars or '@allow-any' in its description",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 18},
+						End:      hcl.Pos{Line: 8, Column: 21},
+					},
+				},
+			},
+		},
+		{
+			Name: "variables without type",
+			Content: `
+variable "my_var" {
+  default = "my value"
+}
+
+variable "my_untyped_var" {}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "variables without type, type required",
+			Content: `
+variable "my_var" {
+  default = {
+    name = "my name"
+    size = 2
+  }
+}
+
+variable "my_untyped_var" {
+  description = "my description"
+}
+
+variable "my_ignored_var" {}`,
+			Config: `
+rule "terraform_any_type_variables" {
+  enabled = true
+
+  require_type = true
+  ignore_vars  = ["my_ignored_var"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformAnyTypeVariables(),
+					Message: "variable 'my_var' has no type declared, consider 'object({ name = string, size = number })' inferred from its default; exempt it with ignore_vars or '@allow-any' in its description",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 18},
+					},
+				},
+				{
+					Rule:    NewTerraformAnyTypeVariables(),
+					Message: "variable 'my_untyped_var' has no type declared; exempt it with ignore_vars or '@allow-any' in its description",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 9, Column: 1},
+						End:      hcl.Pos{Line: 9, Column: 26},
+					},
+				},
+			},
+		},
+		{
+			Name: "variable without type, type required and fixed",
+			Content: `
+variable "my_var" {
+  default = ["a", "b"]
+}
+
+variable "my_single_line_var" { default = 1 }`,
+			Config: `
+rule "terraform_any_type_variables" {
+  enabled = true
+
+  require_type      = true
+  fix_inferred_type = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformAnyTypeVariables(),
+					Message: "variable 'my_var' has no type declared, consider 'list(string)' inferred from its default; exempt it with ignore_vars or '@allow-any' in its description",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 18},
+					},
+				},
+				{
+					Rule:    NewTerraformAnyTypeVariables(),
+					Message: "variable 'my_single_line_var' has no type declared, consider 'number' inferred from its default; exempt it with ignore_vars or '@allow-any' in its description",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 30},
+					},
+				},
+			},
+			Fixed: `
+variable "my_var" {
+  type    = list(string)
+  default = ["a", "b"]
+}
+
+variable "my_single_line_var" { default = 1 }`,
+		},
+		{
+			Name: "variables with 'any' type ignored by patterns and description marker",
+			Content: `
+variable "extra_tags" {
+  type = any
+}
+
+variable "network_overrides" {
+  type = any
+}
+
+variable "my_passthrough" {
+  description = "Passed through to the child module as is. @allow-any"
+  type        = any
+}
+
+variable "my_settings" {
+  type = object({
+    passthrough = any
+    other       = any
+  })
+}
+
+variable "my_var" {
+  type = any
+}`,
+			Config: `
+rule "terraform_any_type_variables" {
+  enabled = true
+
+  ignore_vars   = ["extra_*", "/_overrides$/"]
+  allowed_paths = ["*.passthrough"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformAnyTypeVariables(),
+					Message: "variable 'my_settings' has 'any' type declared at 'my_settings.other'; exempt it with allowed_paths",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 18, Column: 19},
+						End:      hcl.Pos{Line: 18, Column: 22},
+					},
+				},
+				{
+					Rule:    NewTerraformAnyTypeVariables(),
+					Message: "variable 'my_var' has 'any' type declared; exempt it with ignore_vars or '@allow-any' in its description",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 23, Column: 10},
+						End:      hcl.Pos{Line: 23, Column: 13},
+					},
+				},
+			},
+		},
+		{
+			Name: "variable with 'any' type and description marker disabled",
+			Content: `
+variable "my_passthrough" {
+  description = "@allow-any"
+  type        = any
+}`,
+			Config: `
+rule "terraform_any_type_variables" {
+  enabled = true
+
+  description_marker = ""
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformAnyTypeVariables(),
+					Message: "variable 'my_passthrough' has 'any' type declared; exempt it with ignore_vars",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 17},
+						End:      hcl.Pos{Line: 4, Column: 20},
+					},
+				},
+			},
+		},
 	}
 
 	rule := NewTerraformAnyTypeVariables()
@@ -102,10 +519,54 @@ variable "my_var" {
 			}
 
 			helper.AssertIssues(t, test.Expected, runner.Issues)
+
+			want := map[string]string{}
+			if test.Fixed != "" {
+				want["main.tf"] = test.Fixed
+			}
+			helper.AssertChanges(t, want, runner.Changes())
 		})
 	}
 }
 
+func Test_TerraformAnyTypeVariables_JSON(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{
+		"main.tf.json": `{
+  "variable": {
+    "my_var": {
+      "default": {
+        "a": "b"
+      }
+    }
+  }
+}`,
+		".tflint.hcl": `
+rule "terraform_any_type_variables" {
+  enabled = true
+
+  require_type      = true
+  fix_inferred_type = true
+}`,
+	})
+
+	if err := NewTerraformAnyTypeVariables().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewTerraformAnyTypeVariables(),
+			Message: "variable 'my_var' has no type declared, consider 'map(string)' inferred from its default; exempt it with ignore_vars or '@allow-any' in its description",
+			Range: hcl.Range{
+				Filename: "main.tf.json",
+				Start:    hcl.Pos{Line: 3, Column: 15},
+				End:      hcl.Pos{Line: 3, Column: 16},
+			},
+		},
+	}, runner.Issues)
+	helper.AssertChanges(t, map[string]string{}, runner.Changes())
+}
+
 const testTerraformAnyTypeVariablesConfig = `
 rule "terraform_any_type_variables" {
   enabled     = true
@@ -3,6 +3,8 @@ package rules
 import (
 	"bytes"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
@@ -15,6 +17,108 @@ type TerraformMetaArguments struct {
 	tflint.DefaultRule
 }
 
+type terraformMetaArgumentsConfig struct {
+	Layouts []terraformMetaArgumentsLayoutConfig `hclext:"layout,block"`
+}
+
+// terraformMetaArgumentsLayoutConfig overrides the expected layout of a block type.
+// Omitted attributes fall back to the default layout of the block type.
+type terraformMetaArgumentsLayoutConfig struct {
+	BlockType       string     `hclext:"block_type,label"`
+	Leading         [][]string `hclext:"leading,optional"`
+	Trailing        [][]string `hclext:"trailing,optional"`
+	BlankLine       *bool      `hclext:"blank_line,optional"`
+	BlankLineBefore []string   `hclext:"blank_line_before,optional"`
+	BlankLineAtEnd  *bool      `hclext:"blank_line_at_end,optional"`
+	BlockOrder      []string   `hclext:"block_order,optional"`
+}
+
+// metaArgumentsLayout describes where meta arguments are expected within a block body.
+// Each group is a list of arguments that are placed together without blank lines,
+// and groups are separated from each other by a single blank line if BlankLine is set.
+type metaArgumentsLayout struct {
+	// Leading groups are expected right after the block definition, in order.
+	Leading [][]string
+	// Trailing groups are expected right before the end of the block, in order.
+	Trailing [][]string
+	// BlankLine requires a blank line between groups and after the leading groups.
+	BlankLine bool
+	// BlankLineBefore lists the trailing arguments requiring a blank line before them when they
+	// come first among the trailing groups, if BlankLine is set.
+	BlankLineBefore []string
+	// BlankLineAtEnd requires the blank line after the leading groups even when they end the block,
+	// if BlankLine is set.
+	BlankLineAtEnd bool
+	// BlockOrder is the expected order of the other nested blocks by type.
+	// "*" stands for any nested block type not listed.
+	BlockOrder []string
+}
+
+// defaultMetaArgumentsLayouts holds the expected layout for each supported block type.
+var defaultMetaArgumentsLayouts = map[string]metaArgumentsLayout{
+	"module": {
+		Leading:         [][]string{{"source"}, {"count", "for_each"}, {"providers"}},
+		Trailing:        [][]string{{"depends_on"}},
+		BlankLine:       true,
+		BlankLineBefore: []string{"depends_on"},
+		BlankLineAtEnd:  true,
+	},
+	"resource": {
+		Leading:         [][]string{{"count", "for_each"}, {"provider"}},
+		Trailing:        [][]string{{"depends_on"}, {"lifecycle"}},
+		BlankLine:       true,
+		BlankLineBefore: []string{"depends_on"},
+		BlankLineAtEnd:  true,
+		BlockOrder:      []string{"*", "dynamic", "connection", "provisioner"},
+	},
+	"data": {
+		Leading:         [][]string{{"count", "for_each"}, {"provider"}},
+		Trailing:        [][]string{{"depends_on"}, {"lifecycle"}},
+		BlankLine:       true,
+		BlankLineBefore: []string{"depends_on"},
+		BlankLineAtEnd:  true,
+		BlockOrder:      []string{"*", "dynamic"},
+	},
+	"import": {
+		Leading:   [][]string{{"for_each"}, {"provider"}, {"to", "id", "identity"}},
+		BlankLine: true,
+	},
+	"moved": {
+		Leading:   [][]string{{"from", "to"}},
+		BlankLine: true,
+	},
+	"removed": {
+		Leading:    [][]string{{"from"}},
+		Trailing:   [][]string{{"lifecycle"}},
+		BlankLine:  true,
+		BlockOrder: []string{"connection", "provisioner"},
+	},
+	"check": {
+		BlankLine:  true,
+		BlockOrder: []string{"data", "assert"},
+	},
+	// The layout of 'lifecycle' blocks nested in other blocks.
+	"lifecycle": {
+		BlankLine:  true,
+		BlockOrder: []string{"precondition", "postcondition"},
+	},
+}
+
+// nestedMetaArgumentsBlocks lists the nested block types checked with their own layout, by enclosing block type.
+var nestedMetaArgumentsBlocks = map[string][]string{
+	"resource": {"lifecycle"},
+	"data":     {"lifecycle"},
+	"removed":  {"lifecycle"},
+	"check":    {"data"},
+}
+
+// metaArgumentsItem is an attribute or a nested block within a block body.
+type metaArgumentsItem struct {
+	Name  string
+	Range hcl.Range
+	Block bool
+}
+
 // NewTerraformMetaArguments returns a new rule
 func NewTerraformMetaArguments() *TerraformMetaArguments {
 	return &TerraformMetaArguments{}
@@ -40,235 +144,465 @@ func (r *TerraformMetaArguments) Link() string {
 	return "https://github.com/styumyum/tflint-ruleset-yumyum/docs/rules/terraform_meta_arguments.md"
 }
 
-// Check checks whether variables have type
+// Check checks the arrangement of meta arguments in module, resource, data, import, moved, removed and check blocks
 func (r *TerraformMetaArguments) Check(runner tflint.Runner) error {
+	config := &terraformMetaArgumentsConfig{}
+
+	if err := runner.DecodeRuleConfig(r.Name(), config); err != nil {
+		return err
+	}
+
+	layouts, err := config.getLayouts()
+	if err != nil {
+		return err
+	}
+
 	files, err := runner.GetFiles()
 	if err != nil {
 		return err
 	}
 
 	for _, file := range files {
-		fileContents, _, diags := file.Body.PartialContent(&hcl.BodySchema{
-			Blocks: []hcl.BlockHeaderSchema{
-				{
-					Type:       "module",
-					LabelNames: []string{"name"},
-				},
-				{
-					Type:       "resource",
-					LabelNames: []string{"type", "name"},
-				},
-				{
-					Type:       "data",
-					LabelNames: []string{"type", "name"},
-				},
-			},
-		})
-		if diags.HasErrors() {
-			return diags
+		// The arrangement is only meaningful for HCL native syntax.
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
 		}
 
-		for _, fileContent := range fileContents.Blocks {
-			var content *hcl.BodyContent
-			switch fileContent.Type {
-			case "module":
-				content, _, diags = fileContent.Body.PartialContent(&hcl.BodySchema{
-					Attributes: []hcl.AttributeSchema{
-						{Name: "source"},
-						{Name: "count"},
-						{Name: "for_each"},
-						{Name: "providers"},
-					},
-				})
-			case "resource", "data":
-				content, _, diags = fileContent.Body.PartialContent(&hcl.BodySchema{
-					Attributes: []hcl.AttributeSchema{
-						{Name: "count"},
-						{Name: "for_each"},
-						{Name: "provider"},
-					},
-					Blocks: []hcl.BlockHeaderSchema{
-						{
-							Type:       "lifecycle",
-							LabelNames: []string{},
-						},
-					},
-				})
-			}
-			if diags.HasErrors() {
-				return diags
+		// Each block is checked with its own native body and ranges, so blocks with
+		// colliding labels such as 'resource "a_b" "c"' and 'data "a" "b_c"' never mix up.
+		for _, block := range body.Blocks {
+			layout, exists := layouts[block.Type]
+			if !exists || block.Type == "lifecycle" {
+				continue
 			}
 
-			// currentRange is used to check the arrangement of 'source', 'count'/'for_each' and 'providers'.
-			// lastAttr is used to check new line after last argument.
-			currentRange := fileContent.DefRange
-			var lastAttr hcl.Attribute
-
-			// Check meta argument 'source'.
-			source, sourceExist := content.Attributes["source"]
-			if sourceExist {
-				// 'source' is expected to be placed under 'module "my_module" {'.
-				if currentRange.End.Line+1 != source.Range.Start.Line {
-					if err := runner.EmitIssue(
-						r,
-						fmt.Sprintf("%s '%s' has invalid 'source' meta argument arrangement", fileContent.Type, strings.Join(fileContent.Labels, ".")),
-						source.Range,
-					); err != nil {
-						return err
-					}
-					continue
-				}
-				currentRange = source.Range
-				lastAttr = *source
+			blockName := metaArgumentsBlockName(block)
+			fixed, err := r.checkBlock(runner, file, block, blockName, layout, true)
+			if err != nil {
+				return err
 			}
 
-			// Check meta arguments 'count' or 'for_each'.
-			// Only one of 'count' and 'for_each' are allowed in Terraform meta argument.
-			count, countExist := content.Attributes["count"]
-			forEach, forEachExist := content.Attributes["for_each"]
-			if countExist {
-				checkLine := currentRange.End.Line
-				if sourceExist {
-					// 'count' is expected to be placed under 'source = "./my-module/"' with an extra newline.
-					checkLine += 2
-				} else {
-					// 'count' is expected to be placed under 'resource "my_type" "my_name" {'.
-					checkLine += 1
-				}
-				if checkLine != count.Range.Start.Line {
-					if err := runner.EmitIssue(
-						r,
-						fmt.Sprintf("%s '%s' has invalid 'count' meta argument arrangement", fileContent.Type, strings.Join(fileContent.Labels, ".")),
-						count.Range,
-					); err != nil {
-						return err
-					}
+			for _, nested := range block.Body.Blocks {
+				if !slices.Contains(nestedMetaArgumentsBlocks[block.Type], nested.Type) {
 					continue
 				}
-				currentRange = count.Range
-				if count.Range.Start.Line > lastAttr.Range.Start.Line {
-					lastAttr = *count
-				}
-			} else if forEachExist {
-				checkLine := currentRange.End.Line
-				if sourceExist {
-					// 'for_each' is expected to be placed under 'source = "./my-module/"' with an extra newline.
-					checkLine += 2
-				} else {
-					// 'for_each' is expected to be placed under 'resource "my_type" "my_name" {'.
-					checkLine += 1
-				}
-				if checkLine != forEach.Range.Start.Line {
-					if err := runner.EmitIssue(
-						r,
-						fmt.Sprintf("%s '%s' has invalid 'for_each' meta argument arrangement", fileContent.Type, fileContent.Labels[0]),
-						forEach.Range,
-					); err != nil {
-						return err
-					}
-					continue
-				}
-				currentRange = forEach.Range
-				if forEach.Range.Start.Line > lastAttr.Range.Start.Line {
-					lastAttr = *forEach
+				// The fix of the enclosing block rewrites the nested block as well,
+				// so the nested block can only be fixed on the next run in that case.
+				nestedName := blockName + " " + metaArgumentsBlockName(nested)
+				if _, err := r.checkBlock(runner, file, nested, nestedName, layouts[nested.Type], !fixed); err != nil {
+					return err
 				}
 			}
+		}
+	}
 
-			// Check meta arguments 'providers' or 'provider'.
-			var providerExist bool
-			var provider *hcl.Attribute
-			var errMsg string
-
-			switch fileContent.Type {
-			case "module":
-				provider, providerExist = content.Attributes["providers"]
-				errMsg = fmt.Sprintf("%s '%s' has invalid 'providers' meta argument arrangement",
-					fileContent.Type, strings.Join(fileContent.Labels, "."))
-			case "resource", "data": // Combined case since they have identical handling
-				provider, providerExist = content.Attributes["provider"]
-				errMsg = fmt.Sprintf("%s '%s' has invalid 'provider' meta argument arrangement",
-					fileContent.Type, strings.Join(fileContent.Labels, "."))
-			}
+	return nil
+}
 
-			if providerExist {
-				checkLine := currentRange.End.Line
-				if sourceExist || countExist || forEachExist {
-					// 'providers' is expected to be placed under 'count=0' or 'for_each={}' with an extra newline.
-					checkLine += 2
-				} else {
-					// 'providers' is expected to be placed under 'resource "my_type" "my_name" {'.
-					checkLine += 1
-				}
-				if checkLine != provider.Range.Start.Line {
-					if err := runner.EmitIssue(
-						r,
-						errMsg,
-						provider.Range,
-					); err != nil {
-						return err
-					}
-					continue
-				}
-				currentRange = provider.Range
-				if provider.Range.Start.Line > lastAttr.Range.Start.Line {
-					lastAttr = *provider
+// metaArgumentsBlockName returns the name of the block used in issue messages, e.g. "resource 'aws_instance.main'".
+func metaArgumentsBlockName(block *hclsyntax.Block) string {
+	if len(block.Labels) == 0 {
+		return block.Type
+	}
+	return fmt.Sprintf("%s '%s'", block.Type, strings.Join(block.Labels, "."))
+}
+
+// checkBlock reports every arrangement violation within the given block, and returns whether any was reported.
+// All issues of a block share the same fix, which rewrites the block body into the expected layout.
+func (r *TerraformMetaArguments) checkBlock(
+	runner tflint.Runner,
+	file *hcl.File,
+	block *hclsyntax.Block,
+	blockName string,
+	layout metaArgumentsLayout,
+	fixable bool,
+) (bool, error) {
+	items := metaArgumentsItems(block.Body)
+	lines := bytes.Split(file.Bytes, []byte("\n"))
+	openLine := block.OpenBraceRange.End.Line
+	closeLine := block.CloseBraceRange.Start.Line
+
+	type issue struct {
+		message string
+		rng     hcl.Range
+	}
+	var issues []issue
+
+	// Check the leading meta arguments, e.g. 'source', 'count'/'for_each' and 'providers'.
+	// Each argument is expected right after its preceding argument, with an extra newline between groups.
+	// The comments placed above an argument belong to it, as the fix moves them together.
+	prevLine := openLine
+	var lastLeading *metaArgumentsItem
+	for _, entry := range presentMetaArguments(items, layout.Leading) {
+		checkLine := prevLine + 1
+		if layout.BlankLine && lastLeading != nil && !entry.sameGroupAs(lastLeading.Name, layout.Leading) {
+			checkLine++
+		}
+		startLine := entry.item.Range.Start.Line
+		for startLine-1 > prevLine && isCommentLine(string(lines[startLine-2])) {
+			startLine--
+		}
+		if startLine != checkLine {
+			issues = append(issues, issue{
+				message: fmt.Sprintf("%s has invalid '%s' meta argument arrangement", blockName, entry.item.Name),
+				rng:     entry.item.Range,
+			})
+		}
+		prevLine = entry.item.Range.End.Line
+		lastLeading = entry.item
+	}
+
+	// Check new line after the last leading meta argument.
+	var lastAttr *metaArgumentsItem
+	for _, entry := range presentMetaArguments(items, layout.Leading) {
+		if lastAttr == nil || entry.item.Range.Start.Line > lastAttr.Range.Start.Line {
+			lastAttr = entry.item
+		}
+	}
+	if layout.BlankLine && lastAttr != nil && lastAttr.Range.End.Line < len(lines) && (layout.BlankLineAtEnd || lastAttr.Range.End.Line < closeLine-1) {
+		checkLine := lines[lastAttr.Range.End.Line]
+		if strings.TrimSpace(string(checkLine)) != "" {
+			issues = append(issues, issue{
+				message: fmt.Sprintf("%s has missing new line after meta argument '%s'", blockName, lastAttr.Name),
+				rng:     lastAttr.Range,
+			})
+		}
+	}
+
+	// Check the trailing meta arguments, e.g. 'depends_on' and 'lifecycle'.
+	// They are checked backwards from the end of the block, which must directly follow the last one.
+	trailing := presentMetaArguments(items, layout.Trailing)
+	nextLine := closeLine
+	var nextTrailing *metaArgumentsItem
+	for i := len(trailing) - 1; i >= 0; i-- {
+		entry := trailing[i]
+		checkLine := nextLine - 1
+		if layout.BlankLine && nextTrailing != nil && !entry.sameGroupAs(nextTrailing.Name, layout.Trailing) {
+			checkLine--
+		}
+		if entry.item.Range.End.Line != checkLine {
+			issues = append(issues, issue{
+				message: fmt.Sprintf("%s has invalid '%s' meta argument arrangement", blockName, entry.item.Name),
+				rng:     entry.item.Range,
+			})
+		}
+		nextLine = entry.item.Range.Start.Line
+		nextTrailing = entry.item
+	}
+
+	// Check new line before the first trailing meta argument, e.g. 'depends_on', skipping the comments placed above it.
+	var firstAttr *metaArgumentsItem
+	for _, entry := range trailing {
+		if firstAttr == nil || entry.item.Range.Start.Line < firstAttr.Range.Start.Line {
+			firstAttr = entry.item
+		}
+	}
+	if layout.BlankLine && firstAttr != nil && slices.Contains(layout.BlankLineBefore, firstAttr.Name) {
+		checkLine := firstAttr.Range.Start.Line - 1
+		for checkLine > openLine && isCommentLine(string(lines[checkLine-1])) {
+			checkLine--
+		}
+		if checkLine > openLine && strings.TrimSpace(string(lines[checkLine-1])) != "" {
+			issues = append(issues, issue{
+				message: fmt.Sprintf("%s has missing new line before meta argument '%s'", blockName, firstAttr.Name),
+				rng:     firstAttr.Range,
+			})
+		}
+	}
+
+	// Check the order of other nested blocks, e.g. 'dynamic' blocks after plain blocks.
+	// A block is misplaced if a block expected after it has already appeared.
+	maxIndex := -1
+	for _, item := range orderedBlocks(items, layout) {
+		index := blockOrderIndex(item.Name, layout.BlockOrder)
+		if index < maxIndex {
+			issues = append(issues, issue{
+				message: fmt.Sprintf("%s has invalid '%s' block arrangement", blockName, item.Name),
+				rng:     item.Range,
+			})
+			continue
+		}
+		maxIndex = index
+	}
+
+	if len(issues) == 0 {
+		return false, nil
+	}
+
+	fix := func(f tflint.Fixer) error {
+		if !fixable {
+			return tflint.ErrFixNotSupported
+		}
+		return fixMetaArgumentsLayout(f, file, block, items, layout)
+	}
+	for _, issue := range issues {
+		if err := runner.EmitIssueWithFix(r, issue.message, issue.rng, fix); err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+// getLayouts merges the configured layouts into the default layouts of each block type.
+func (config *terraformMetaArgumentsConfig) getLayouts() (map[string]metaArgumentsLayout, error) {
+	layouts := make(map[string]metaArgumentsLayout, len(defaultMetaArgumentsLayouts))
+	for blockType, layout := range defaultMetaArgumentsLayouts {
+		layouts[blockType] = layout
+	}
+
+	for _, layoutConfig := range config.Layouts {
+		layout, exists := layouts[layoutConfig.BlockType]
+		if !exists {
+			return nil, fmt.Errorf("`%s` is unsupported block type for layout", layoutConfig.BlockType)
+		}
+
+		if layoutConfig.Leading != nil {
+			layout.Leading = layoutConfig.Leading
+		}
+		if layoutConfig.Trailing != nil {
+			layout.Trailing = layoutConfig.Trailing
+		}
+		if layoutConfig.BlankLine != nil {
+			layout.BlankLine = *layoutConfig.BlankLine
+		}
+		if layoutConfig.BlankLineBefore != nil {
+			layout.BlankLineBefore = layoutConfig.BlankLineBefore
+		}
+		if layoutConfig.BlankLineAtEnd != nil {
+			layout.BlankLineAtEnd = *layoutConfig.BlankLineAtEnd
+		}
+		if layoutConfig.BlockOrder != nil {
+			layout.BlockOrder = layoutConfig.BlockOrder
+		}
+
+		layouts[layoutConfig.BlockType] = layout
+	}
+
+	return layouts, nil
+}
+
+// orderedBlocks returns the nested blocks constrained by the block order of the layout, in source order.
+// Blocks placed by the leading or trailing groups are excluded.
+func orderedBlocks(items []*metaArgumentsItem, layout metaArgumentsLayout) []*metaArgumentsItem {
+	var blocks []*metaArgumentsItem
+	for _, item := range items {
+		if !item.Block || layout.placed(item.Name) || blockOrderIndex(item.Name, layout.BlockOrder) < 0 {
+			continue
+		}
+		blocks = append(blocks, item)
+	}
+	return blocks
+}
+
+// blockOrderIndex returns the position of the block type in the order, or -1 if it is not constrained.
+func blockOrderIndex(blockType string, order []string) int {
+	if index := slices.Index(order, blockType); index >= 0 {
+		return index
+	}
+	return slices.Index(order, "*")
+}
+
+// placed returns whether the argument belongs to the leading or trailing groups.
+func (layout metaArgumentsLayout) placed(name string) bool {
+	for _, group := range slices.Concat(layout.Leading, layout.Trailing) {
+		if slices.Contains(group, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// metaArgumentsEntry is a meta argument found in a block body, with its position in the layout.
+type metaArgumentsEntry struct {
+	item  *metaArgumentsItem
+	group int
+}
+
+// sameGroupAs returns whether the given argument name belongs to the same layout group as the entry.
+func (e metaArgumentsEntry) sameGroupAs(name string, groups [][]string) bool {
+	return slices.Contains(groups[e.group], name)
+}
+
+// presentMetaArguments returns the first occurrence of each meta argument of the given groups, in layout order.
+func presentMetaArguments(items []*metaArgumentsItem, groups [][]string) []metaArgumentsEntry {
+	var entries []metaArgumentsEntry
+	for group, names := range groups {
+		for _, name := range names {
+			for _, item := range items {
+				if item.Name == name {
+					entries = append(entries, metaArgumentsEntry{item: item, group: group})
+					break
 				}
 			}
+		}
+	}
+	return entries
+}
+
+// metaArgumentsItems returns the attributes and nested blocks of a body, sorted by their position.
+func metaArgumentsItems(body *hclsyntax.Body) []*metaArgumentsItem {
+	var items []*metaArgumentsItem
+	for _, attr := range body.Attributes {
+		items = append(items, &metaArgumentsItem{Name: attr.Name, Range: attr.SrcRange})
+	}
+	for _, block := range body.Blocks {
+		items = append(items, &metaArgumentsItem{Name: block.Type, Range: block.Range(), Block: true})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Range.Start.Byte < items[j].Range.Start.Byte
+	})
+	return items
+}
+
+// fixMetaArgumentsLayout rewrites the block body so that meta arguments follow the given layout.
+// Every argument is moved together with the comments and blank lines placed above it,
+// so no content of the body is lost.
+func fixMetaArgumentsLayout(f tflint.Fixer, file *hcl.File, block *hclsyntax.Block, items []*metaArgumentsItem, layout metaArgumentsLayout) error {
+	openLine := block.OpenBraceRange.End.Line
+	closeLine := block.CloseBraceRange.Start.Line
+	for _, item := range items {
+		// Arguments sharing a line with the braces, e.g. 'resource "a" "b" { count = 1 }', cannot be moved safely.
+		if item.Range.Start.Line == openLine || item.Range.End.Line == closeLine {
+			return tflint.ErrFixNotSupported
+		}
+	}
+
+	lines := strings.Split(string(file.Bytes), "\n")
+
+	// Split the body into chunks, one per item, each including the comments placed above the item.
+	type chunk struct {
+		text        string
+		blankBefore bool
+	}
+	chunks := make(map[*metaArgumentsItem]chunk, len(items))
+	prevLine := openLine
+	for _, item := range items {
+		text, blankBefore := trimBlankLines(lines[prevLine:item.Range.End.Line])
+		chunks[item] = chunk{text: text, blankBefore: blankBefore}
+		prevLine = item.Range.End.Line
+	}
+	tail, tailBlankBefore := trimBlankLines(lines[prevLine : closeLine-1])
 
-			// Check new line after 'source', 'count', 'for_each', 'providers' or 'provider.
-			if sourceExist || countExist || forEachExist || providerExist {
-				lines := bytes.Split(file.Bytes, []byte("\n"))
-				checkLine := lines[lastAttr.Range.End.Line]
-				if strings.TrimSpace(string(checkLine)) != "" {
-					if err := runner.EmitIssue(
-						r,
-						fmt.Sprintf("%s '%s' has missing new line after meta argument '%s'", fileContent.Type, strings.Join(fileContent.Labels, "."), lastAttr.Name),
-						lastAttr.Range,
-					); err != nil {
-						return err
-					}
+	placed := make(map[*metaArgumentsItem]bool, len(items))
+	groupText := func(names []string) string {
+		var texts []string
+		for _, name := range names {
+			for _, item := range items {
+				if item.Name == name && !placed[item] {
+					texts = append(texts, chunks[item].text)
+					placed[item] = true
 				}
 			}
+		}
+		return strings.Join(texts, "\n")
+	}
 
-			// Check meta argument 'lifecycle'.
-			lifeCycleBlocks := content.Blocks.OfType("lifecycle")
-			if len(lifeCycleBlocks) > 0 {
-				var lifecycleFullRange hcl.Range
-				var contentEndLine int
-				contentFile, err := runner.GetFile(fileContent.DefRange.Filename)
-				if err != nil {
-					return err
-				}
+	separator := "\n"
+	if layout.BlankLine {
+		separator = "\n\n"
+	}
 
-				// Locate the resource to get completed resource range.
-				resourceFileBody := contentFile.Body.(*hclsyntax.Body)
-				for _, fileBlock := range resourceFileBody.Blocks {
-					if fileBlock.Type == "resource" || fileBlock.Type == "data" {
-						if fileBlock.Labels[0]+fileBlock.Labels[1] == fileContent.Labels[0]+fileContent.Labels[1] {
-							contentEndLine = fileBlock.Range().End.Line
-							for _, bodyBlock := range fileBlock.Body.Blocks {
-								if bodyBlock.Type == "lifecycle" {
-									lifecycleFullRange = bodyBlock.Range()
-									break
-								}
-							}
-							break
-						}
-					}
-				}
+	var leading []string
+	for _, names := range layout.Leading {
+		leading = append(leading, groupText(names))
+	}
+	var trailing []string
+	for _, names := range layout.Trailing {
+		trailing = append(trailing, groupText(names))
+	}
 
-				if lifecycleFullRange.End.Line+1 != contentEndLine {
-					if err := runner.EmitIssue(
-						r,
-						fmt.Sprintf("%s '%s' has invalid 'lifecycle' meta argument arrangement", fileContent.Type, strings.Join(fileContent.Labels, ".")),
-						lifecycleFullRange,
-					); err != nil {
-						return err
-					}
-				}
+	// Nested blocks constrained by the block order are sorted within the positions they already occupy.
+	blocks := orderedBlocks(items, layout)
+	sorted := slices.Clone(blocks)
+	slices.SortStableFunc(sorted, func(a, b *metaArgumentsItem) int {
+		return blockOrderIndex(a.Name, layout.BlockOrder) - blockOrderIndex(b.Name, layout.BlockOrder)
+	})
+	texts := make(map[*metaArgumentsItem]string, len(blocks))
+	for _, item := range blocks {
+		texts[item] = chunks[item].text
+	}
+	for i, item := range blocks {
+		chunks[item] = chunk{text: texts[sorted[i]], blankBefore: chunks[item].blankBefore}
+	}
+
+	// Other arguments keep their relative order and spacing.
+	var body strings.Builder
+	appendBody := func(text string, blankBefore bool) {
+		if body.Len() > 0 {
+			body.WriteString("\n")
+			if blankBefore {
+				body.WriteString("\n")
 			}
 		}
+		body.WriteString(text)
+	}
+	for _, item := range items {
+		if !placed[item] {
+			appendBody(chunks[item].text, chunks[item].blankBefore)
+		}
+	}
+	if tail != "" {
+		appendBody(tail, tailBlankBefore)
+	}
+	isEmpty := func(group string) bool { return group == "" }
+	leadingText := strings.Join(slices.DeleteFunc(leading, isEmpty), separator)
+	groups := []string{
+		leadingText,
+		body.String(),
+		strings.Join(slices.DeleteFunc(trailing, isEmpty), separator),
 	}
 
-	return nil
+	// The body is always set apart from the meta arguments by a blank line.
+	groups = slices.DeleteFunc(groups, isEmpty)
+	text := "\n" + strings.Join(groups, "\n\n") + "\n"
+	if layout.BlankLine && layout.BlankLineAtEnd && len(groups) == 1 && groups[0] == leadingText {
+		// The leading meta arguments end the block, but are still followed by a blank line.
+		text += "\n"
+	}
+
+	lineStarts := lineStartBytes(file.Bytes)
+	rng := hcl.Range{
+		Filename: block.OpenBraceRange.Filename,
+		Start:    hcl.Pos{Line: openLine, Column: len(lines[openLine-1]) + 1, Byte: lineStarts[openLine] - 1},
+		End:      hcl.Pos{Line: closeLine, Column: 1, Byte: lineStarts[closeLine-1]},
+	}
+
+	return f.ReplaceText(rng, text)
+}
+
+// isCommentLine returns whether the line only contains a comment.
+func isCommentLine(line string) bool {
+	line = strings.TrimSpace(line)
+	for _, prefix := range []string{"#", "//", "/*", "*"} {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// trimBlankLines joins the lines after removing blank lines at both ends.
+// It also returns whether any blank line was removed from the beginning.
+func trimBlankLines(lines []string) (string, bool) {
+	start, end := 0, len(lines)
+	for start < end && strings.TrimSpace(lines[start]) == "" {
+		start++
+	}
+	for end > start && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	return strings.Join(lines[start:end], "\n"), start > 0
+}
+
+// lineStartBytes returns the byte offset of the beginning of each line, indexed from zero.
+func lineStartBytes(src []byte) []int {
+	starts := []int{0}
+	for i, b := range src {
+		if b == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
 }
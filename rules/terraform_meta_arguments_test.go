@@ -11,7 +11,9 @@ func Test_TerraformMetaArguments(t *testing.T) {
 	tests := []struct {
 		Name     string
 		Content  string
+		Config   string
 		Expected helper.Issues
+		Fixed    string
 	}{
 		{
 			Name: "source only in module",
@@ -173,7 +175,24 @@ module "my_module" {
 						End:      hcl.Pos{Line: 5, Column: 26},
 					},
 				},
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "module 'my_module' has invalid 'count' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 12},
+					},
+				},
 			},
+			Fixed: `
+module "my_module" {
+  source = "./my-module/"
+
+  count = 3
+
+  name = "my name"
+}`,
 		},
 		{
 			Name: "count, provider in resource, invalid arrangement",
@@ -195,7 +214,24 @@ resource "foo" "my_resource" {
 						End:      hcl.Pos{Line: 5, Column: 12},
 					},
 				},
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "resource 'foo.my_resource' has invalid 'provider' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 25},
+					},
+				},
 			},
+			Fixed: `
+resource "foo" "my_resource" {
+  count = 3
+
+  provider = foo.default
+
+  name = "my name"
+}`,
 		},
 		{
 			Name: "count, provider and lifecycle in resource, invalid arrangement",
@@ -220,6 +256,16 @@ resource "foo" "my_resource" {
 					},
 				},
 			},
+			Fixed: `
+resource "foo" "my_resource" {
+  count = 3
+
+  provider = foo.default
+
+  name = "my name"
+
+  lifecycle {}
+}`,
 		},
 		{
 			Name: "source, count and providers in module, invalid arrangement",
@@ -243,7 +289,26 @@ module "my_module" {
 						End:      hcl.Pos{Line: 7, Column: 12},
 					},
 				},
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "module 'my_module' has invalid 'providers' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 3},
+						End:      hcl.Pos{Line: 5, Column: 17},
+					},
+				},
 			},
+			Fixed: `
+module "my_module" {
+  source = "./my-module/"
+
+  count = 3
+
+  providers = {}
+
+  name = "my name"
+}`,
 		},
 		{
 			Name: "source only in module, missing new line",
@@ -263,6 +328,12 @@ module "my_module" {
 					},
 				},
 			},
+			Fixed: `
+module "my_module" {
+  source = "./my-module/"
+
+  name = "my name"
+}`,
 		},
 		{
 			Name: "source and count only in module, missing new line",
@@ -284,6 +355,14 @@ module "my_module" {
 					},
 				},
 			},
+			Fixed: `
+module "my_module" {
+  source = "./my-module/"
+
+  count = 3
+
+  name = "my name"
+}`,
 		},
 		{
 			Name: "source, count and providers in module, missing new line",
@@ -307,19 +386,1117 @@ module "my_module" {
 					},
 				},
 			},
+			Fixed: `
+module "my_module" {
+  source = "./my-module/"
+
+  count = 3
+
+  providers = {}
+
+  name = "my name"
+}`,
+		},
+		{
+			Name: "for_each, provider and lifecycle in resource with comments, invalid arrangement",
+			Content: `
+resource "foo" "my_resource" {
+  # Name of the resource
+  name = "my name" # inline comment
+
+  # Regional provider
+  provider = foo.default
+
+  lifecycle {
+    create_before_destroy = true
+  }
+
+  // One resource per key
+  for_each = {}
+  tags     = {}
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "resource 'foo.my_resource' has invalid 'for_each' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 14, Column: 3},
+						End:      hcl.Pos{Line: 14, Column: 16},
+					},
+				},
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "resource 'foo.my_resource' has missing new line after meta argument 'for_each'",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 14, Column: 3},
+						End:      hcl.Pos{Line: 14, Column: 16},
+					},
+				},
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "resource 'foo.my_resource' has invalid 'provider' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 25},
+					},
+				},
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "resource 'foo.my_resource' has invalid 'lifecycle' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 9, Column: 3},
+						End:      hcl.Pos{Line: 11, Column: 4},
+					},
+				},
+			},
+			Fixed: `
+resource "foo" "my_resource" {
+  // One resource per key
+  for_each = {}
+
+  # Regional provider
+  provider = foo.default
+
+  # Name of the resource
+  name = "my name" # inline comment
+  tags = {}
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}`,
+		},
+		{
+			Name: "source at the end of module, missing new line",
+			Content: `
+module "my_module" {
+  source = "./my-module"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "module 'my_module' has missing new line after meta argument 'source'",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 25},
+					},
+				},
+			},
+			Fixed: `
+module "my_module" {
+  source = "./my-module"
+
+}`,
+		},
+		{
+			Name: "source at the end of module with custom layout",
+			Content: `
+module "my_module" {
+  source = "./my-module"
+}`,
+			Config: `
+rule "terraform_meta_arguments" {
+  enabled = true
+
+  layout "module" {
+    blank_line_at_end = false
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "source with a comment in module, invalid arrangement",
+			Content: `
+module "my_module" {
+  name = "my name"
+  # The module source
+  source = "./my-module"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "module 'my_module' has invalid 'source' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 3},
+						End:      hcl.Pos{Line: 5, Column: 25},
+					},
+				},
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "module 'my_module' has missing new line after meta argument 'source'",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 3},
+						End:      hcl.Pos{Line: 5, Column: 25},
+					},
+				},
+			},
+			Fixed: `
+module "my_module" {
+  # The module source
+  source = "./my-module"
+
+  name = "my name"
+}`,
+		},
+		{
+			Name: "count in single line resource, invalid arrangement",
+			Content: `
+resource "foo" "my_resource" { count = 3 }`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "resource 'foo.my_resource' has invalid 'count' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 32},
+						End:      hcl.Pos{Line: 2, Column: 41},
+					},
+				},
+			},
+		},
+		{
+			Name: "source, version, for_each and providers in module with custom layout",
+			Content: `
+module "my_module" {
+  source  = "my/module"
+  version = "1.0.0"
+
+  for_each = {}
+
+  providers = {}
+
+  name = "my name"
+}`,
+			Config: `
+rule "terraform_meta_arguments" {
+  enabled = true
+
+  layout "module" {
+    leading = [["source", "version"], ["count", "for_each"], ["providers"]]
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "source, version and count in module with custom layout, invalid arrangement",
+			Content: `
+module "my_module" {
+  source = "my/module"
+
+  count = 3
+
+  version = "1.0.0"
+
+  name = "my name"
+}`,
+			Config: `
+rule "terraform_meta_arguments" {
+  enabled = true
+
+  layout "module" {
+    leading = [["source"], ["version"], ["count", "for_each"]]
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "module 'my_module' has invalid 'version' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 20},
+					},
+				},
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "module 'my_module' has invalid 'count' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 3},
+						End:      hcl.Pos{Line: 5, Column: 12},
+					},
+				},
+			},
+			Fixed: `
+module "my_module" {
+  source = "my/module"
+
+  version = "1.0.0"
+
+  count = 3
+
+  name = "my name"
+}`,
+		},
+		{
+			Name: "count, provider and depends_on in resource without blank lines, invalid arrangement",
+			Content: `
+resource "foo" "my_resource" {
+  count = 3
+
+  provider = foo.default
+
+  depends_on = []
+
+  name = "my name"
+}`,
+			Config: `
+rule "terraform_meta_arguments" {
+  enabled = true
+
+  layout "resource" {
+    trailing   = [["depends_on"], ["lifecycle"]]
+    blank_line = false
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "resource 'foo.my_resource' has invalid 'provider' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 3},
+						End:      hcl.Pos{Line: 5, Column: 25},
+					},
+				},
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "resource 'foo.my_resource' has invalid 'depends_on' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 18},
+					},
+				},
+			},
+			Fixed: `
+resource "foo" "my_resource" {
+  count    = 3
+  provider = foo.default
+
+  name = "my name"
+
+  depends_on = []
+}`,
+		},
+		{
+			Name: "lifecycle in resource with trailing check disabled",
+			Content: `
+resource "foo" "my_resource" {
+  lifecycle {}
+
+  name = "my name"
+}`,
+			Config: `
+rule "terraform_meta_arguments" {
+  enabled = true
+
+  layout "resource" {
+    trailing = []
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "depends_on and lifecycle in resource",
+			Content: `
+resource "foo" "my_resource" {
+  count = 3
+
+  name = "my name"
+
+  # Wait for the network
+  depends_on = [foo.network]
+
+  lifecycle {}
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "depends_on in module",
+			Content: `
+module "my_module" {
+  source = "./my-module/"
+
+  name = "my name"
+
+  depends_on = [foo.network]
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "depends_on in resource, invalid arrangement",
+			Content: `
+resource "foo" "my_resource" {
+  depends_on = [foo.network]
+
+  name = "my name"
+
+  lifecycle {}
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "resource 'foo.my_resource' has invalid 'depends_on' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 29},
+					},
+				},
+			},
+			Fixed: `
+resource "foo" "my_resource" {
+  name = "my name"
+
+  depends_on = [foo.network]
+
+  lifecycle {}
+}`,
+		},
+		{
+			Name: "depends_on in data source, missing new line",
+			Content: `
+data "foo" "my_data" {
+  name       = "my name"
+  depends_on = [foo.network]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "data 'foo.my_data' has missing new line before meta argument 'depends_on'",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 29},
+					},
+				},
+			},
+			Fixed: `
+data "foo" "my_data" {
+  name = "my name"
+
+  depends_on = [foo.network]
+}`,
+		},
+		{
+			Name: "lifecycle in resource without depends_on, no new line",
+			Content: `
+resource "foo" "my_resource" {
+  name = "my name"
+  lifecycle {
+    create_before_destroy = true
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "depends_on in module, invalid arrangement",
+			Content: `
+module "my_module" {
+  source = "./my-module/"
+
+  depends_on = [foo.network]
+
+  name = "my name"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "module 'my_module' has invalid 'depends_on' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 3},
+						End:      hcl.Pos{Line: 5, Column: 29},
+					},
+				},
+			},
+			Fixed: `
+module "my_module" {
+  source = "./my-module/"
+
+  name = "my name"
+
+  depends_on = [foo.network]
+}`,
+		},
+		{
+			Name: "nested blocks in resource",
+			Content: `
+resource "foo" "my_resource" {
+  name = "my name"
+
+  ebs_block_device {}
+
+  dynamic "tag" {
+    for_each = {}
+
+    content {}
+  }
+
+  connection {}
+
+  provisioner "local-exec" {}
+
+  lifecycle {
+    ignore_changes = []
+
+    precondition {}
+
+    postcondition {}
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "nested blocks in resource, invalid arrangement",
+			Content: `
+resource "foo" "my_resource" {
+  name = "my name"
+
+  # Bootstrap
+  provisioner "local-exec" {}
+
+  dynamic "tag" {
+    for_each = {}
+
+    content {}
+  }
+  ebs_block_device {}
+
+  lifecycle {}
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "resource 'foo.my_resource' has invalid 'dynamic' block arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 3},
+						End:      hcl.Pos{Line: 12, Column: 4},
+					},
+				},
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "resource 'foo.my_resource' has invalid 'ebs_block_device' block arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 13, Column: 3},
+						End:      hcl.Pos{Line: 13, Column: 22},
+					},
+				},
+			},
+			Fixed: `
+resource "foo" "my_resource" {
+  name = "my name"
+
+  ebs_block_device {}
+
+  dynamic "tag" {
+    for_each = {}
+
+    content {}
+  }
+  # Bootstrap
+  provisioner "local-exec" {}
+
+  lifecycle {}
+}`,
+		},
+		{
+			Name: "precondition and postcondition in lifecycle, invalid arrangement",
+			Content: `
+resource "foo" "my_resource" {
+  name = "my name"
+
+  lifecycle {
+    postcondition {}
+
+    precondition {}
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "resource 'foo.my_resource' lifecycle has invalid 'precondition' block arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 5},
+						End:      hcl.Pos{Line: 8, Column: 20},
+					},
+				},
+			},
+			Fixed: `
+resource "foo" "my_resource" {
+  name = "my name"
+
+  lifecycle {
+    precondition {}
+
+    postcondition {}
+  }
+}`,
+		},
+		{
+			Name: "lifecycle in resource and postcondition in lifecycle, invalid arrangement",
+			Content: `
+resource "foo" "my_resource" {
+  lifecycle {
+    postcondition {}
+    precondition {}
+  }
+
+  name = "my name"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "resource 'foo.my_resource' has invalid 'lifecycle' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 6, Column: 4},
+					},
+				},
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "resource 'foo.my_resource' lifecycle has invalid 'precondition' block arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 5},
+						End:      hcl.Pos{Line: 5, Column: 20},
+					},
+				},
+			},
+			Fixed: `
+resource "foo" "my_resource" {
+  name = "my name"
+
+  lifecycle {
+    postcondition {}
+    precondition {}
+  }
+}`,
+		},
+		{
+			Name: "provisioner before dynamic allowed by custom block order",
+			Content: `
+resource "foo" "my_resource" {
+  provisioner "local-exec" {}
+
+  dynamic "tag" {}
+}`,
+			Config: `
+rule "terraform_meta_arguments" {
+  enabled = true
+
+  layout "resource" {
+    block_order = ["provisioner", "*"]
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "import, moved, removed and check blocks",
+			Content: `
+import {
+  for_each = {}
+
+  provider = foo.default
+
+  to = foo.my_resource[each.key]
+  id = each.value
+}
+
+moved {
+  from = foo.old
+  to   = foo.new
+}
+
+removed {
+  from = foo.my_resource
+
+  provisioner "local-exec" {}
+
+  lifecycle {
+    destroy = false
+  }
+}
+
+check "health" {
+  data "foo" "my_data" {
+    name = "my name"
+  }
+
+  assert {
+    condition     = true
+    error_message = "unhealthy"
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "import block, invalid arrangement",
+			Content: `
+import {
+  id       = "my-id"
+  to       = foo.my_resource
+  for_each = {}
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "import has invalid 'for_each' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 3},
+						End:      hcl.Pos{Line: 5, Column: 16},
+					},
+				},
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "import has invalid 'to' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 29},
+					},
+				},
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "import has invalid 'id' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 21},
+					},
+				},
+			},
+			Fixed: `
+import {
+  for_each = {}
+
+  to = foo.my_resource
+  id = "my-id"
+}`,
+		},
+		{
+			Name: "moved block, invalid arrangement",
+			Content: `
+moved {
+  to   = foo.new
+  from = foo.old
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "moved has invalid 'from' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 17},
+					},
+				},
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "moved has invalid 'to' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 17},
+					},
+				},
+			},
+			Fixed: `
+moved {
+  from = foo.old
+  to   = foo.new
+}`,
+		},
+		{
+			Name: "removed block, invalid arrangement",
+			Content: `
+removed {
+  lifecycle {
+    destroy = false
+  }
+
+  from = foo.my_resource
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "removed has invalid 'from' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 25},
+					},
+				},
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "removed has invalid 'lifecycle' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 5, Column: 4},
+					},
+				},
+			},
+			Fixed: `
+removed {
+  from = foo.my_resource
+
+  lifecycle {
+    destroy = false
+  }
+}`,
+		},
+		{
+			Name: "check block, invalid arrangement",
+			Content: `
+check "health" {
+  assert {
+    condition     = true
+    error_message = "unhealthy"
+  }
+
+  data "foo" "my_data" {
+    name = "my name"
+
+    provider = foo.default
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "check 'health' has invalid 'data' block arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 3},
+						End:      hcl.Pos{Line: 12, Column: 4},
+					},
+				},
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "check 'health' data 'foo.my_data' has invalid 'provider' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 5},
+						End:      hcl.Pos{Line: 11, Column: 27},
+					},
+				},
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "check 'health' data 'foo.my_data' has missing new line after meta argument 'provider'",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 5},
+						End:      hcl.Pos{Line: 11, Column: 27},
+					},
+				},
+			},
+			Fixed: `
+check "health" {
+  data "foo" "my_data" {
+    name = "my name"
+
+    provider = foo.default
+  }
+
+  assert {
+    condition     = true
+    error_message = "unhealthy"
+  }
+}`,
+		},
+	}
+
+	rule := NewTerraformMetaArguments()
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{
+				"main.tf":     test.Content,
+				".tflint.hcl": test.Config,
+			})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, test.Expected, runner.Issues)
+
+			want := map[string]string{}
+			if test.Fixed != "" {
+				want["main.tf"] = test.Fixed
+			}
+			helper.AssertChanges(t, want, runner.Changes())
+
+			if test.Fixed == "" {
+				return
+			}
+
+			// Fixing the fixed content again settles without any issue left. Nested blocks
+			// rewritten by the fix of their enclosing block are only fixed on the next run.
+			content := test.Fixed
+			for run := 0; ; run++ {
+				runner := helper.TestRunner(t, map[string]string{
+					"main.tf":     content,
+					".tflint.hcl": test.Config,
+				})
+
+				if err := rule.Check(runner); err != nil {
+					t.Fatalf("Unexpected error occurred: %s", err)
+				}
+
+				changes := runner.Changes()
+				if len(changes) == 0 {
+					helper.AssertIssues(t, helper.Issues{}, runner.Issues)
+					break
+				}
+				if run == 1 {
+					t.Fatalf("Fix does not settle:\n%s", changes["main.tf"])
+				}
+				content = string(changes["main.tf"])
+			}
+		})
+	}
+}
+
+func Test_TerraformMetaArguments_SameNamedBlocks(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+		Fixed    map[string]string
+	}{
+		{
+			Name: "resource and data source with colliding labels in one file, invalid data source",
+			Files: map[string]string{
+				"main.tf": `
+resource "a_b" "c" {
+  name = "my name"
+
+  lifecycle {}
+}
+
+data "a" "b_c" {
+  lifecycle {}
+
+  name = "my name"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "data 'a.b_c' has invalid 'lifecycle' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 9, Column: 3},
+						End:      hcl.Pos{Line: 9, Column: 15},
+					},
+				},
+			},
+			Fixed: map[string]string{
+				"main.tf": `
+resource "a_b" "c" {
+  name = "my name"
+
+  lifecycle {}
+}
+
+data "a" "b_c" {
+  name = "my name"
+
+  lifecycle {}
+}`,
+			},
+		},
+		{
+			Name: "resource and data source with colliding labels in one file, invalid resource",
+			Files: map[string]string{
+				"main.tf": `
+data "a" "b_c" {
+  name = "my name"
+
+  lifecycle {}
+}
+
+resource "a_b" "c" {
+  lifecycle {}
+
+  name = "my name"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "resource 'a_b.c' has invalid 'lifecycle' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 9, Column: 3},
+						End:      hcl.Pos{Line: 9, Column: 15},
+					},
+				},
+			},
+			Fixed: map[string]string{
+				"main.tf": `
+data "a" "b_c" {
+  name = "my name"
+
+  lifecycle {}
+}
+
+resource "a_b" "c" {
+  name = "my name"
+
+  lifecycle {}
+}`,
+			},
+		},
+		{
+			Name: "resource and data source with the same labels in one file, invalid data source",
+			Files: map[string]string{
+				"main.tf": `
+resource "foo" "my_resource" {
+  name = "my name"
+
+  lifecycle {}
+}
+
+data "foo" "my_resource" {
+  lifecycle {}
+
+  name = "my name"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "data 'foo.my_resource' has invalid 'lifecycle' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 9, Column: 3},
+						End:      hcl.Pos{Line: 9, Column: 15},
+					},
+				},
+			},
+			Fixed: map[string]string{
+				"main.tf": `
+resource "foo" "my_resource" {
+  name = "my name"
+
+  lifecycle {}
+}
+
+data "foo" "my_resource" {
+  name = "my name"
+
+  lifecycle {}
+}`,
+			},
+		},
+		{
+			Name: "resource and data source whose concatenated labels collide, invalid data source",
+			Files: map[string]string{
+				"main.tf": `
+resource "a" "bc" {
+  name = "my name"
+
+  lifecycle {}
+}
+
+data "ab" "c" {
+  lifecycle {}
+
+  name = "my name"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "data 'ab.c' has invalid 'lifecycle' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 9, Column: 3},
+						End:      hcl.Pos{Line: 9, Column: 15},
+					},
+				},
+			},
+			Fixed: map[string]string{
+				"main.tf": `
+resource "a" "bc" {
+  name = "my name"
+
+  lifecycle {}
+}
+
+data "ab" "c" {
+  name = "my name"
+
+  lifecycle {}
+}`,
+			},
+		},
+		{
+			Name: "resource and data source with the same labels across files, invalid data source",
+			Files: map[string]string{
+				"main.tf": `
+resource "foo" "my_resource" {
+  name = "my name"
+
+  lifecycle {}
+}`,
+				"data.tf": `
+data "foo" "my_resource" {
+  lifecycle {
+    postcondition {}
+  }
+  name = "my name"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArguments(),
+					Message: "data 'foo.my_resource' has invalid 'lifecycle' meta argument arrangement",
+					Range: hcl.Range{
+						Filename: "data.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 5, Column: 4},
+					},
+				},
+			},
+			Fixed: map[string]string{
+				"data.tf": `
+data "foo" "my_resource" {
+  name = "my name"
+
+  lifecycle {
+    postcondition {}
+  }
+}`,
+			},
 		},
 	}
 
 	rule := NewTerraformMetaArguments()
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
-			runner := helper.TestRunner(t, map[string]string{"main.tf": test.Content})
+			runner := helper.TestRunner(t, test.Files)
 
 			if err := rule.Check(runner); err != nil {
 				t.Fatalf("Unexpected error occurred: %s", err)
 			}
 
 			helper.AssertIssues(t, test.Expected, runner.Issues)
+			helper.AssertChanges(t, test.Fixed, runner.Changes())
 		})
 	}
 }
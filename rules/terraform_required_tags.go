@@ -1,11 +1,19 @@
 package rules
 
 import (
+	"embed"
 	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"regexp"
 	"slices"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
@@ -22,8 +30,82 @@ func NewTerraformRequiredTags() *TerraformRequiredTags {
 }
 
 type terraformRequiredTagsConfig struct {
-	Tags              []string `hclext:"tags,optional"`
-	ExcludedResources []string `hclext:"excluded_resources,optional"`
+	Tags              []string                            `hclext:"tags,optional"`
+	ExcludedResources []string                            `hclext:"excluded_resources,optional"`
+	ResourcePrefixes  []terraformRequiredTagsPrefixConfig `hclext:"resource_prefix,block"`
+	ResourceTypes     []terraformRequiredTagsTypeConfig   `hclext:"resource_type,block"`
+	TagValues         []terraformRequiredTagsValueConfig  `hclext:"tag,block"`
+	UndeterminedTags  string                              `hclext:"undetermined_tags,optional"`
+	ModuleSources     []terraformRequiredTagsModuleConfig `hclext:"module,block"`
+	KeyCase           string                              `hclext:"key_case,optional"`
+	KeyCaseExceptions []string                            `hclext:"key_case_exceptions,optional"`
+}
+
+// terraformRequiredTagsModuleConfig configures the tags passed to the module calls whose source matches the pattern.
+type terraformRequiredTagsModuleConfig struct {
+	Source       string   `hclext:"source,label"`
+	Attribute    *string  `hclext:"attribute,optional"`
+	RequiredTags []string `hclext:"required_tags,optional"`
+	Required     bool     `hclext:"required,optional"`
+}
+
+// requiredTagsModuleSource is the tags configuration of the module calls whose source matches a pattern.
+type requiredTagsModuleSource struct {
+	// Pattern matches the sources of the module calls.
+	Pattern *regexp.Regexp
+	// Attribute is the name of the module argument holding the tags.
+	Attribute string
+	// RequiredTags are required in addition to the `tags` of the rule.
+	RequiredTags []string
+	// Required reports module calls without the argument.
+	Required bool
+}
+
+// terraformRequiredTagsValueConfig constrains the values of the tag named by its label.
+type terraformRequiredTagsValueConfig struct {
+	Key       string   `hclext:"key,label"`
+	Values    []string `hclext:"values,optional"`
+	Pattern   string   `hclext:"pattern,optional"`
+	NonEmpty  bool     `hclext:"non_empty,optional"`
+	MaxLength int      `hclext:"max_length,optional"`
+}
+
+// terraformRequiredTagsPrefixConfig configures the tags of the resource types starting with the prefix.
+type terraformRequiredTagsPrefixConfig struct {
+	Prefix       string   `hclext:"prefix,label"`
+	Attribute    *string  `hclext:"attribute,optional"`
+	RequiredTags []string `hclext:"required_tags,optional"`
+	KeyPattern   *string  `hclext:"key_pattern,optional"`
+}
+
+// terraformRequiredTagsTypeConfig configures the tags of the resource type named by its label.
+type terraformRequiredTagsTypeConfig struct {
+	Type         string   `hclext:"type,label"`
+	Tags         []string `hclext:"tags,optional"`
+	RequiredTags []string `hclext:"required_tags,optional"`
+}
+
+// requiredTagsPrefix is the tags configuration of the resource types starting with a prefix.
+type requiredTagsPrefix struct {
+	// Attribute is the name of the attribute holding the tags.
+	Attribute string
+	// Tags are required instead of the `tags` of the rule, if any. Only set by `resource_type`.
+	Tags []string
+	// RequiredTags are required in addition to the `tags` of the rule.
+	RequiredTags []string
+	// KeyPattern is the pattern every tag key must match, if any.
+	KeyPattern *regexp.Regexp
+}
+
+// defaultRequiredTagsPrefixes are the tags configurations of the supported providers.
+// AWS resources require a `Name` tag, Google resources are labeled with `labels`
+// whose keys are lowercase, and Azure tag names cannot contain `<>%&\?/`.
+var defaultRequiredTagsPrefixes = map[string]requiredTagsPrefix{
+	"aws_":          {Attribute: "tags", RequiredTags: []string{"Name"}},
+	"azurerm_":      {Attribute: "tags", KeyPattern: regexp.MustCompile(`^[^<>%&\\?/]{1,512}$`)},
+	"google_":       {Attribute: "labels", KeyPattern: regexp.MustCompile(`^[\p{Ll}\p{Lo}][\p{Ll}\p{Lo}\p{N}_-]{0,62}$`)},
+	"alicloud_":     {Attribute: "tags"},
+	"tencentcloud_": {Attribute: "tags"},
 }
 
 // Name returns the rule name
@@ -54,6 +136,15 @@ func (r *TerraformRequiredTags) Check(runner tflint.Runner) error {
 		return err
 	}
 
+	prefixes, err := config.getPrefixes()
+	if err != nil {
+		return err
+	}
+
+	if err := config.validateExcludedResources(); err != nil {
+		return err
+	}
+
 	// Set default required tags if none are specified
 	if len(config.Tags) == 0 {
 		config.Tags = []string{
@@ -66,16 +157,26 @@ func (r *TerraformRequiredTags) Check(runner tflint.Runner) error {
 		}
 	}
 
-	// Get and evaluate `local.tags`
-	localTagsAttr, err := r.getLocalTags(runner)
+	switch config.UndeterminedTags {
+	case "":
+		config.UndeterminedTags = "report"
+	case "report", "ignore":
+	default:
+		return fmt.Errorf("`%s` is unsupported value for `undetermined_tags`", config.UndeterminedTags)
+	}
+
+	policy, err := config.getTagEntryPolicy()
 	if err != nil {
 		return err
 	}
 
-	var localTags cty.Value
-	var localTagKeys []string
+	resolver, err := newTagKeyResolver(runner)
+	if err != nil {
+		return err
+	}
 
-	if localTagsAttr == nil {
+	// Check the existence of `local.tags`
+	if localTagsExpr, exists := resolver.locals["tags"]; !exists {
 		err := runner.EmitIssue(
 			r,
 			"missing required local variable `tags`",
@@ -88,35 +189,42 @@ func (r *TerraformRequiredTags) Check(runner tflint.Runner) error {
 			return err
 		}
 	} else {
-		err := runner.EvaluateExpr(localTagsAttr.Expr, func(val cty.Value) error {
-			localTags = val
-			return nil
-		}, nil)
-		if err != nil {
+		if err := r.checkTagEntries(runner, localTagsExpr, policy); err != nil {
 			return err
 		}
+	}
 
-		// Store all the key from `local.tags` if applicable
-		if !localTags.IsKnown() || localTags.IsNull() || !localTags.CanIterateElements() {
-			return nil
-		} else {
-			for it := localTags.ElementIterator(); it.Next(); {
-				k, _ := it.Element()
-				localTagKeys = append(localTagKeys, k.AsString())
-			}
+	defaultTags, err := r.getProviderDefaultTags(runner)
+	if err != nil {
+		return err
+	}
+
+	defaultTagsKeys := make([]string, 0, len(defaultTags))
+	for key := range defaultTags {
+		defaultTagsKeys = append(defaultTagsKeys, key)
+	}
+	slices.Sort(defaultTagsKeys)
+	for _, key := range defaultTagsKeys {
+		if err := r.checkTagEntries(runner, defaultTags[key], policy); err != nil {
+			return err
 		}
 	}
 
 	// Parse resources and check their `tags` blocks
+	attributes := []hclext.AttributeSchema{{Name: "tags"}, {Name: "provider"}}
+	for _, name := range tagsAttributeNames(prefixes) {
+		if name != "tags" && name != "provider" {
+			attributes = append(attributes, hclext.AttributeSchema{Name: name})
+		}
+	}
+
 	resources, err := runner.GetModuleContent(&hclext.BodySchema{
 		Blocks: []hclext.BlockSchema{
 			{
 				Type:       "resource",
 				LabelNames: []string{"type", "name"},
 				Body: &hclext.BodySchema{
-					Attributes: []hclext.AttributeSchema{
-						{Name: "tags"},
-					},
+					Attributes: attributes,
 				},
 			},
 		},
@@ -126,143 +234,1252 @@ func (r *TerraformRequiredTags) Check(runner tflint.Runner) error {
 	}
 
 	for _, resource := range resources.Blocks {
-		if slices.Contains(config.ExcludedResources, resource.Labels[0]) {
+		if config.isExcluded(resource.Labels[0], resource.Labels[1]) {
 			continue
 		}
 
-		tagsAttr, tagsExist := resource.Body.Attributes["tags"]
-		if !tagsExist {
-			continue
+		// Tags defined in `default_tags` of the provider are applied to every resource
+		defaultTagKeys, defaultTagsKnown, hasDefaultTags, err := r.resourceDefaultTagKeys(resolver, resource, defaultTags)
+		if err != nil {
+			return err
 		}
 
+		prefix := config.lookupRequiredTags(prefixes, resource.Labels[0])
+		tagsAttrName := prefix.Attribute
+		issueRange := resource.DefRange
+
 		var tagKeys []string
-		var evalErr error
-
-		switch expr := tagsAttr.Expr.(type) {
-		// Usage of function calls like merge(local.tags, { ... })
-		case *hclsyntax.FunctionCallExpr:
-			if expr.Name == "merge" {
-				for _, arg := range expr.Args {
-					// If the argument is `local.tags`, inject keys directly
-					if varExpr, ok := arg.(*hclsyntax.ScopeTraversalExpr); ok && varExpr.Traversal.RootName() == "local" {
-						tagKeys = append(tagKeys, localTagKeys...)
-						continue
-					}
-
-					// Otherwise, evaluate and extract keys
-					err := runner.EvaluateExpr(arg, func(val cty.Value) error {
-						if val.IsKnown() && val.CanIterateElements() {
-							for it := val.ElementIterator(); it.Next(); {
-								k, _ := it.Element()
-								tagKeys = append(tagKeys, k.AsString())
-							}
-						}
-						return nil
-					}, nil)
-
-					if err != nil {
-						evalErr = err
-						break
-					}
-				}
-			} else {
-				// Ignore any terraform function calls other than `merge`
-				continue
+		tagKeysKnown := true
+		if tagsAttr, tagsExist := resource.Body.Attributes[tagsAttrName]; tagsExist {
+			keys, known, err := resolver.resolve(tagsAttr.Expr)
+			if err != nil {
+				return err
 			}
 
-		// Direct use of local variable on tags
-		// E.g. tags = local.tags
-		case *hclsyntax.ScopeTraversalExpr:
-			if expr.Traversal.RootName() == "local" {
-				tagKeys = append(tagKeys, localTagKeys...)
-			} else {
+			tagKeys = keys
+			tagKeysKnown = known
+			issueRange = tagsAttr.Expr.Range()
+
+			if err := r.checkTagEntries(runner, tagsAttr.Expr, policy.withTags(prefix.Tags, prefix.RequiredTags)); err != nil {
+				return err
+			}
+		} else {
+			// Resources that cannot be tagged are not expected to have tags
+			if !taggableResources[resource.Labels[0]] {
 				continue
 			}
 
-		default:
-			evalErr = runner.EvaluateExpr(tagsAttr.Expr, func(val cty.Value) error {
-				if val.IsKnown() && val.CanIterateElements() {
-					for it := val.ElementIterator(); it.Next(); {
-						k, _ := it.Element()
-						tagKeys = append(tagKeys, k.AsString())
-					}
+			if !hasDefaultTags {
+				err := runner.EmitIssue(
+					r,
+					fmt.Sprintf("%s '%s' is missing the `%s` attribute", resource.Labels[0], resource.Labels[1], tagsAttrName),
+					resource.DefRange,
+				)
+				if err != nil {
+					return err
 				}
-				return nil
-			}, nil)
-		}
-
-		if evalErr != nil {
-			return evalErr
+				continue
+			}
 		}
+		tagKeys = append(defaultTagKeys, tagKeys...)
+		tagKeysKnown = tagKeysKnown && defaultTagsKnown
 
 		// Remove any duplicated keys if any
+		slices.Sort(tagKeys)
 		tagKeys = slices.Compact(tagKeys)
 
-		var missing []string
+		requiredTags := config.Tags
+		if prefix.Tags != nil {
+			requiredTags = prefix.Tags
+		}
+
+		err = r.checkRequiredTags(runner, config, resource.Labels[0], resource.Labels[1], tagKeys, tagKeysKnown, requiredTags, prefix.RequiredTags, issueRange)
+		if err != nil {
+			return err
+		}
 
-		for _, requiredTags := range config.Tags {
-			if !slices.Contains(tagKeys, requiredTags) {
-				missing = append(missing, requiredTags)
-			}
+		if prefix.KeyPattern == nil {
+			continue
 		}
+		for _, tagKey := range tagKeys {
+			if prefix.KeyPattern.MatchString(tagKey) {
+				continue
+			}
 
-		// Output linting error if any missing tags are present
-		if len(missing) > 0 {
 			err := runner.EmitIssue(
 				r,
-				fmt.Sprintf("%s '%s' is missing required tags: [%s]", resource.Labels[0], resource.Labels[1], strings.Join(missing, ", ")),
-				tagsAttr.Expr.Range(),
+				fmt.Sprintf("%s '%s' has tag key `%s` not matching `%s`", resource.Labels[0], resource.Labels[1], tagKey, prefix.KeyPattern),
+				issueRange,
 			)
 			if err != nil {
 				return err
 			}
 		}
+	}
+
+	return r.checkModules(runner, config, resolver, policy)
+}
+
+// checkModules checks the tags passed to the module calls matching the configured sources.
+func (r *TerraformRequiredTags) checkModules(runner tflint.Runner, config *terraformRequiredTagsConfig, resolver *tagKeyResolver, policy *tagEntryPolicy) error {
+	sources, err := config.getModuleSources()
+	if err != nil {
+		return err
+	}
+
+	attributes := []hclext.AttributeSchema{{Name: "source"}}
+	for _, source := range sources {
+		if !slices.ContainsFunc(attributes, func(attr hclext.AttributeSchema) bool { return attr.Name == source.Attribute }) {
+			attributes = append(attributes, hclext.AttributeSchema{Name: source.Attribute})
+		}
+	}
+
+	modules, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "module",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: attributes,
+				},
+			},
+		},
+	}, &tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return err
+	}
+
+	for _, module := range modules.Blocks {
+		if config.isExcluded("module", module.Labels[0]) {
+			continue
+		}
+
+		sourceAttr, exists := module.Body.Attributes["source"]
+		if !exists {
+			continue
+		}
+
+		var sourceValue string
+		if err := runner.EvaluateExpr(sourceAttr.Expr, &sourceValue, nil); err != nil {
+			return err
+		}
+
+		source, ok := lookupRequiredTagsModuleSource(sources, sourceValue)
+		if !ok {
+			continue
+		}
+
+		tagsAttr, exists := module.Body.Attributes[source.Attribute]
+		if !exists {
+			if !source.Required {
+				continue
+			}
 
-		// If resource is AWS Cloud resource, check if `Name` tag key exists
-		if r.isAwsResource(resource.Labels[0]) && !slices.Contains(tagKeys, "Name") {
 			err := runner.EmitIssue(
 				r,
-				fmt.Sprintf("%s '%s' is missing required tag: Name", resource.Labels[0], resource.Labels[1]),
-				tagsAttr.Expr.Range(),
+				fmt.Sprintf("module '%s' is missing the `%s` argument", module.Labels[0], source.Attribute),
+				module.DefRange,
 			)
 			if err != nil {
 				return err
 			}
+			continue
+		}
+
+		if err := r.checkTagEntries(runner, tagsAttr.Expr, policy.withTags(source.RequiredTags)); err != nil {
+			return err
+		}
+
+		tagKeys, known, err := resolver.resolve(tagsAttr.Expr)
+		if err != nil {
+			return err
+		}
+		slices.Sort(tagKeys)
+		tagKeys = slices.Compact(tagKeys)
+
+		err = r.checkRequiredTags(runner, config, "module", module.Labels[0], tagKeys, known, config.Tags, source.RequiredTags, tagsAttr.Expr.Range())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkRequiredTags reports the tags and the additional required tags that are missing from the
+// tag keys of a resource or a module call. If the tag keys are not all known, the tags may be
+// missing only in appearance, so this is reported according to `undetermined_tags` instead.
+func (r *TerraformRequiredTags) checkRequiredTags(runner tflint.Runner, config *terraformRequiredTagsConfig, kind, name string, tagKeys []string, known bool, tags, requiredTags []string, issueRange hcl.Range) error {
+	var missing []string
+	for _, requiredTag := range tags {
+		if !slices.Contains(tagKeys, requiredTag) {
+			missing = append(missing, requiredTag)
+		}
+	}
+
+	var missingRequiredTags []string
+	for _, requiredTag := range requiredTags {
+		if !slices.Contains(tagKeys, requiredTag) && !slices.Contains(tags, requiredTag) {
+			missingRequiredTags = append(missingRequiredTags, requiredTag)
+		}
+	}
+
+	if len(missing) == 0 && len(missingRequiredTags) == 0 {
+		return nil
+	}
+
+	// Tags that cannot be determined statically may hold the missing tags
+	if !known {
+		if config.UndeterminedTags != "report" {
+			return nil
+		}
+		return runner.EmitIssue(
+			r,
+			fmt.Sprintf("%s '%s' has tags that cannot be determined", kind, name),
+			issueRange,
+		)
+	}
+
+	// Output linting error if any missing tags are present
+	if len(missing) > 0 {
+		err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("%s '%s' is missing required tags: [%s]", kind, name, strings.Join(missing, ", ")),
+			issueRange,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Check the additional required tags, e.g. `Name` for AWS resources
+	for _, requiredTag := range missingRequiredTags {
+		err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("%s '%s' is missing required tag: %s", kind, name, requiredTag),
+			issueRange,
+		)
+		if err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-// Function to determine whether resource has `aws_` prefix
-func (r *TerraformRequiredTags) isAwsResource(resource string) bool {
-	return strings.HasPrefix(resource, "aws_")
+// getTagPatterns compiles the value patterns of the tags, keyed by the tag key.
+func (c *terraformRequiredTagsConfig) getTagPatterns() (map[string]*regexp.Regexp, error) {
+	patterns := make(map[string]*regexp.Regexp)
+	for _, tag := range c.TagValues {
+		if tag.Pattern == "" {
+			continue
+		}
+
+		pattern, err := regexp.Compile(tag.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("`%s` is invalid pattern for tag `%s`: %w", tag.Pattern, tag.Key, err)
+		}
+		patterns[tag.Key] = pattern
+	}
+	return patterns, nil
 }
 
-// Function to determine whether local variable `tags` exists.
-func (r *TerraformRequiredTags) getLocalTags(runner tflint.Runner) (*hclext.Attribute, error) {
-	locals, err := runner.GetModuleContent(&hclext.BodySchema{
-		Blocks: []hclext.BlockSchema{
-			{
-				Type: "locals",
-				Body: &hclext.BodySchema{
-					Attributes: []hclext.AttributeSchema{
-						{Name: "tags"},
-					},
-				},
-			},
-		},
-	}, nil)
+// tagEntryPolicy holds the constraints on the entries of the tags written as object expressions.
+type tagEntryPolicy struct {
+	// values constrains the values of the tags, with the compiled value patterns keyed by the tag key.
+	values   []terraformRequiredTagsValueConfig
+	patterns map[string]*regexp.Regexp
+
+	// canonicalKeys maps the normalized form of the configured tag keys to their canonical spelling.
+	canonicalKeys map[string]string
+
+	// keyCase is the name of the format every tag key must match, if any, except keyCaseExceptions.
+	keyCase           string
+	keyCasePattern    *regexp.Regexp
+	keyCaseExceptions []string
+}
 
+// getTagEntryPolicy returns the constraints on the tag entries. The tags required of every
+// resource and the constrained tags are the canonical spellings of their keys.
+func (c *terraformRequiredTagsConfig) getTagEntryPolicy() (*tagEntryPolicy, error) {
+	patterns, err := c.getTagPatterns()
 	if err != nil {
 		return nil, err
 	}
 
-	for _, block := range locals.Blocks {
-		if attr, ok := block.Body.Attributes["tags"]; ok {
-			return attr, nil
+	policy := &tagEntryPolicy{
+		values:            c.TagValues,
+		patterns:          patterns,
+		canonicalKeys:     make(map[string]string),
+		keyCaseExceptions: c.KeyCaseExceptions,
+	}
+
+	switch c.KeyCase {
+	case "", "none":
+	default:
+		pattern, exists := predefinedFormats[c.KeyCase]
+		if !exists {
+			return nil, fmt.Errorf("`%s` is unsupported key case", c.KeyCase)
+		}
+		policy.keyCase = c.KeyCase
+		policy.keyCasePattern = pattern
+	}
+
+	policy.addCanonicalKeys(c.Tags)
+	for _, tag := range c.TagValues {
+		policy.addCanonicalKeys([]string{tag.Key})
+	}
+
+	return policy, nil
+}
+
+// withTags returns a copy of the policy in which the tags required of a resource type or
+// of a module source are canonical spellings too, e.g. `Name` only for AWS resources.
+func (p *tagEntryPolicy) withTags(tags ...[]string) *tagEntryPolicy {
+	policy := *p
+	policy.canonicalKeys = maps.Clone(p.canonicalKeys)
+	for _, keys := range tags {
+		policy.addCanonicalKeys(keys)
+	}
+	return &policy
+}
+
+// addCanonicalKeys adds the spellings of the tag keys, unless a key with the same
+// normalized form has already been added.
+func (p *tagEntryPolicy) addCanonicalKeys(keys []string) {
+	for _, key := range keys {
+		normalized := normalizeTagKey(key)
+		if _, exists := p.canonicalKeys[normalized]; !exists {
+			p.canonicalKeys[normalized] = key
+		}
+	}
+}
+
+// isCanonical returns whether the tag key is spelled as one of the configured tag keys.
+func (p *tagEntryPolicy) isCanonical(key string) bool {
+	return p.canonicalKeys[normalizeTagKey(key)] == key
+}
+
+// checkTagEntries checks the tags written as object entries in the tags expression, including
+// the arguments of `merge`, `try`, `coalesce` and `tomap`, the default of `lookup` and both
+// results of conditionals.
+func (r *TerraformRequiredTags) checkTagEntries(runner tflint.Runner, expr hcl.Expression, policy *tagEntryPolicy) error {
+	switch expr := expr.(type) {
+	case *hclsyntax.ObjectConsExpr:
+		// Keys seen in the object and their number, keyed by their normalized form
+		seen := make(map[string]string)
+		counts := make(map[string]int)
+		for _, item := range expr.Items {
+			if key, ok := tagEntryKey(item.KeyExpr); ok {
+				counts[normalizeTagKey(key)]++
+			}
+		}
+
+		for _, item := range expr.Items {
+			key, ok := tagEntryKey(item.KeyExpr)
+			if !ok {
+				continue
+			}
+
+			normalized := normalizeTagKey(key)
+			if other, exists := seen[normalized]; exists {
+				err := runner.EmitIssue(
+					r,
+					fmt.Sprintf("tag `%s` duplicates tag `%s`", key, other),
+					item.KeyExpr.Range(),
+				)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			seen[normalized] = key
+
+			// Renaming one of the duplicated keys would conflict with the others
+			if counts[normalized] == 1 {
+				if err := r.checkTagKey(runner, policy, key, item.KeyExpr); err != nil {
+					return err
+				}
+			}
+			if err := r.checkTagValues(runner, policy, key, item); err != nil {
+				return err
+			}
+		}
+
+	case *hclsyntax.FunctionCallExpr:
+		// Only the arguments that make up the tags are checked, as the tag keys are resolved,
+		// not e.g. the keys of the lookup table of `lookup`
+		var args []hclsyntax.Expression
+		switch expr.Name {
+		case "merge", "try", "coalesce", "tomap":
+			args = expr.Args
+		case "lookup":
+			if len(expr.Args) > 2 {
+				args = expr.Args[2:]
+			}
+		}
+
+		for _, arg := range args {
+			if err := r.checkTagEntries(runner, arg, policy); err != nil {
+				return err
+			}
+		}
+
+	case *hclsyntax.ConditionalExpr:
+		if err := r.checkTagEntries(runner, expr.TrueResult, policy); err != nil {
+			return err
+		}
+		return r.checkTagEntries(runner, expr.FalseResult, policy)
+
+	case *hclsyntax.ParenthesesExpr:
+		return r.checkTagEntries(runner, expr.Expression, policy)
+	}
+
+	return nil
+}
+
+// checkTagKey reports a tag key that differs from the spelling of a configured tag key only by
+// case or separators, or that does not match the key case, and fixes it to the canonical spelling.
+func (r *TerraformRequiredTags) checkTagKey(runner tflint.Runner, policy *tagEntryPolicy, key string, keyExpr hclsyntax.Expression) error {
+	if policy.isCanonical(key) {
+		return nil
+	}
+
+	var message, canonical string
+	if spelling, exists := policy.canonicalKeys[normalizeTagKey(key)]; exists {
+		message = fmt.Sprintf("tag `%s` must be spelled `%s`", key, spelling)
+		canonical = spelling
+	} else if policy.keyCasePattern != nil && !policy.keyCasePattern.MatchString(key) && !slices.Contains(policy.keyCaseExceptions, key) {
+		message = fmt.Sprintf("tag `%s` must match the %s format", key, policy.keyCase)
+		if converted := convertTagKeyCase(key, policy.keyCase); policy.keyCasePattern.MatchString(converted) {
+			canonical = converted
 		}
+	} else {
+		return nil
+	}
+
+	if canonical == "" {
+		return runner.EmitIssue(r, message, keyExpr.Range())
+	}
+
+	return runner.EmitIssueWithFix(r, message, keyExpr.Range(), func(f tflint.Fixer) error {
+		wrapped := keyExpr
+		if keyExpr, ok := keyExpr.(*hclsyntax.ObjectConsKeyExpr); ok {
+			wrapped = keyExpr.Wrapped
+		}
+
+		// Keep bare keys bare, unless the canonical spelling is not a valid identifier
+		if _, bare := wrapped.(*hclsyntax.ScopeTraversalExpr); bare && hclsyntax.ValidIdentifier(canonical) {
+			return f.ReplaceText(keyExpr.Range(), canonical)
+		}
+		return f.ReplaceText(keyExpr.Range(), f.ValueText(cty.StringVal(canonical)))
+	})
+}
+
+// checkTagValues validates the value of a tag entry against the constraints of the tag.
+// Values that are unknown, e.g. references to resources, or not strings are not checked.
+func (r *TerraformRequiredTags) checkTagValues(runner tflint.Runner, policy *tagEntryPolicy, key string, item hclsyntax.ObjectConsItem) error {
+	for _, constraint := range policy.values {
+		if constraint.Key != key {
+			continue
+		}
+
+		entryRange := hcl.RangeBetween(item.KeyExpr.Range(), item.ValueExpr.Range())
+		for _, valueExpr := range tagValueExprs(item.ValueExpr) {
+			err := runner.EvaluateExpr(valueExpr, func(value cty.Value) error {
+				// Values that are not strings, e.g. objects of lookup tables, are not tag values,
+				// and sensitive values are not disclosed
+				if !value.IsKnown() || value.IsNull() || value.IsMarked() || value.Type() != cty.String {
+					return nil
+				}
+				return r.checkTagValue(runner, constraint, policy.patterns[key], value.AsString(), entryRange)
+			}, nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// tagEntryKey returns the key of a tag entry, if it is known statically.
+func tagEntryKey(keyExpr hclsyntax.Expression) (string, bool) {
+	if key := extractKeyName(keyExpr); key != "" {
+		return key, true
 	}
 
-	return nil, nil
+	if len(keyExpr.Variables()) > 0 {
+		return "", false
+	}
+	val, diags := keyExpr.Value(nil)
+	if diags.HasErrors() || !val.IsKnown() || val.IsNull() || val.Type() != cty.String {
+		return "", false
+	}
+	return val.AsString(), true
+}
+
+// normalizeTagKey returns the tag key in lowercase without separators, so that keys
+// differing only by case or separators, e.g. `CostCenter` and `cost-center`, are equal.
+func normalizeTagKey(key string) string {
+	var b strings.Builder
+	for _, c := range key {
+		if unicode.IsLetter(c) || unicode.IsDigit(c) {
+			b.WriteRune(unicode.ToLower(c))
+		}
+	}
+	return b.String()
+}
+
+// convertTagKeyCase converts the tag key to the key case, by splitting it into words at
+// separators and at case changes, e.g. `CostCenter` is `cost_center` in snake_case.
+func convertTagKeyCase(key, keyCase string) string {
+	var words []string
+	var word []rune
+	runes := []rune(key)
+	for i, c := range runes {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = nil
+			}
+			continue
+		}
+
+		// A word starts at an uppercase letter following a lowercase letter or a digit,
+		// or at the last uppercase letter of an acronym followed by a lowercase letter, e.g. `HTTPServer`.
+		if len(word) > 0 && unicode.IsUpper(c) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+		word = append(word, c)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+
+	converted := strings.Join(words, "_")
+	if keyCase == "snake_case" {
+		converted = strings.ToLower(converted)
+	}
+	return converted
+}
+
+// tagValueExprs returns the possible results of a tag value, so that both results of a
+// conditional are checked whatever the condition evaluates to.
+func tagValueExprs(expr hcl.Expression) []hcl.Expression {
+	switch expr := expr.(type) {
+	case *hclsyntax.ConditionalExpr:
+		return append(tagValueExprs(expr.TrueResult), tagValueExprs(expr.FalseResult)...)
+	case *hclsyntax.ParenthesesExpr:
+		return tagValueExprs(expr.Expression)
+	}
+	return []hcl.Expression{expr}
+}
+
+// checkTagValue reports the value of a tag entry that does not satisfy the constraint.
+func (r *TerraformRequiredTags) checkTagValue(runner tflint.Runner, constraint terraformRequiredTagsValueConfig, pattern *regexp.Regexp, value string, entryRange hcl.Range) error {
+	var message string
+
+	switch {
+	case constraint.NonEmpty && strings.TrimSpace(value) == "":
+		message = fmt.Sprintf("tag `%s` must not be empty", constraint.Key)
+	case len(constraint.Values) > 0 && !slices.Contains(constraint.Values, value):
+		message = fmt.Sprintf("tag `%s` has value `%s` not in [%s]", constraint.Key, value, strings.Join(constraint.Values, ", "))
+	case pattern != nil && !pattern.MatchString(value):
+		message = fmt.Sprintf("tag `%s` has value `%s` not matching `%s`", constraint.Key, value, pattern)
+	case constraint.MaxLength > 0 && utf8.RuneCountInString(value) > constraint.MaxLength:
+		message = fmt.Sprintf("tag `%s` has value `%s` longer than %d characters", constraint.Key, value, constraint.MaxLength)
+	default:
+		return nil
+	}
+
+	return runner.EmitIssue(r, message, entryRange)
+}
+
+// getProviderDefaultTags returns the `default_tags` expression of each provider configuration,
+// keyed by the provider name and its alias if any, e.g. `aws` and `aws.west`.
+func (r *TerraformRequiredTags) getProviderDefaultTags(runner tflint.Runner) (map[string]hcl.Expression, error) {
+	providers, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "provider",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{
+						{Name: "alias"},
+					},
+					Blocks: []hclext.BlockSchema{
+						{
+							Type: "default_tags",
+							Body: &hclext.BodySchema{
+								Attributes: []hclext.AttributeSchema{
+									{Name: "tags"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, &tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return nil, err
+	}
+
+	defaultTags := make(map[string]hcl.Expression)
+	for _, provider := range providers.Blocks {
+		key := provider.Labels[0]
+		if aliasAttr, exists := provider.Body.Attributes["alias"]; exists {
+			var alias string
+			if diags := gohcl.DecodeExpression(aliasAttr.Expr, nil, &alias); diags.HasErrors() {
+				continue
+			}
+			key = fmt.Sprintf("%s.%s", key, alias)
+		}
+
+		for _, block := range provider.Body.Blocks {
+			if tagsAttr, exists := block.Body.Attributes["tags"]; exists {
+				defaultTags[key] = tagsAttr.Expr
+			}
+		}
+	}
+
+	return defaultTags, nil
+}
+
+// resourceDefaultTagKeys returns the tag keys from `default_tags` of the provider configuration used
+// by the resource, whether these keys are known, and whether the provider configuration has `default_tags`.
+// The provider configuration is either set by the `provider` meta argument or implied by the resource type.
+func (r *TerraformRequiredTags) resourceDefaultTagKeys(resolver *tagKeyResolver, resource *hclext.Block, defaultTags map[string]hcl.Expression) ([]string, bool, bool, error) {
+	key, _, _ := strings.Cut(resource.Labels[0], "_")
+	if providerAttr, exists := resource.Body.Attributes["provider"]; exists {
+		traversal, diags := hcl.AbsTraversalForExpr(providerAttr.Expr)
+		if diags.HasErrors() {
+			return nil, true, false, nil
+		}
+
+		key = traversal.RootName()
+		if len(traversal) > 1 {
+			if step, ok := traversal[1].(hcl.TraverseAttr); ok {
+				key = fmt.Sprintf("%s.%s", key, step.Name)
+			}
+		}
+	}
+
+	expr, exists := defaultTags[key]
+	if !exists {
+		return nil, true, false, nil
+	}
+
+	tagKeys, known, err := resolver.resolve(expr)
+	return tagKeys, known, true, err
+}
+
+// tagKeyResolver determines the tag keys of tags expressions statically. It follows references
+// to local values and to the defaults of variables by name, and walks through function calls
+// and conditional expressions.
+type tagKeyResolver struct {
+	runner    tflint.Runner
+	locals    map[string]hcl.Expression
+	variables map[string]hcl.Expression
+
+	// resolving holds the references being resolved, so that cyclic references terminate.
+	resolving map[string]bool
+}
+
+// newTagKeyResolver returns a resolver of the local values and variable defaults of the module.
+func newTagKeyResolver(runner tflint.Runner) (*tagKeyResolver, error) {
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "locals",
+				Body: &hclext.BodySchema{Mode: hclext.SchemaJustAttributesMode},
+			},
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "default"}},
+				},
+			},
+		},
+	}, &tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := &tagKeyResolver{
+		runner:    runner,
+		locals:    make(map[string]hcl.Expression),
+		variables: make(map[string]hcl.Expression),
+		resolving: make(map[string]bool),
+	}
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "locals":
+			for name, attr := range block.Body.Attributes {
+				resolver.locals[name] = attr.Expr
+			}
+		case "variable":
+			if attr, exists := block.Body.Attributes["default"]; exists {
+				resolver.variables[block.Labels[0]] = attr.Expr
+			}
+		}
+	}
+
+	return resolver, nil
+}
+
+// resolve returns the tag keys of the expression, and whether all of them are known.
+// When the keys are not all known, the returned keys are the ones that could be determined.
+func (t *tagKeyResolver) resolve(expr hcl.Expression) ([]string, bool, error) {
+	switch expr := expr.(type) {
+	case *hclsyntax.ParenthesesExpr:
+		return t.resolve(expr.Expression)
+
+	case *hclsyntax.ObjectConsExpr:
+		var keys []string
+		known := true
+		for _, item := range expr.Items {
+			key := extractKeyName(item.KeyExpr)
+			if key == "" {
+				var ok bool
+				var err error
+				if key, ok, err = t.evaluateKey(item.KeyExpr); err != nil {
+					return nil, false, err
+				} else if !ok {
+					known = false
+					continue
+				}
+			}
+			keys = append(keys, key)
+		}
+		return keys, known, nil
+
+	case *hclsyntax.ConditionalExpr:
+		// Only the keys of both results are guaranteed
+		trueKeys, trueKnown, err := t.resolve(expr.TrueResult)
+		if err != nil {
+			return nil, false, err
+		}
+		falseKeys, falseKnown, err := t.resolve(expr.FalseResult)
+		if err != nil {
+			return nil, false, err
+		}
+
+		var keys []string
+		for _, key := range trueKeys {
+			if slices.Contains(falseKeys, key) {
+				keys = append(keys, key)
+			}
+		}
+		return keys, trueKnown && falseKnown, nil
+
+	case *hclsyntax.FunctionCallExpr:
+		return t.resolveFunctionCall(expr)
+
+	case *hclsyntax.ScopeTraversalExpr:
+		switch expr.Traversal.RootName() {
+		case "local", "var":
+			elem, ok, err := t.lookup(expr.Traversal)
+			if err != nil || !ok {
+				return nil, false, err
+			}
+			return t.resolveReference(expr.Traversal, elem)
+		}
+
+	case *hclsyntax.IndexExpr:
+		elem, ok, err := t.index(expr)
+		if err != nil || !ok {
+			return nil, false, err
+		}
+		return t.resolve(elem)
+	}
+
+	return t.evaluate(expr)
+}
+
+// resolveFunctionCall returns the tag keys of the result of `merge`, `try`, `coalesce`,
+// `lookup` and `tomap` function calls. The result of other functions is evaluated.
+func (t *tagKeyResolver) resolveFunctionCall(expr *hclsyntax.FunctionCallExpr) ([]string, bool, error) {
+	switch expr.Name {
+	case "merge":
+		var keys []string
+		known := true
+		for _, arg := range expr.Args {
+			argKeys, argKnown, err := t.resolve(arg)
+			if err != nil {
+				return nil, false, err
+			}
+			keys = append(keys, argKeys...)
+			known = known && argKnown
+		}
+		return keys, known, nil
+
+	case "try", "coalesce":
+		// The result is the first argument that does not fail, or that is not null for `coalesce`.
+		// Whether an unknown argument fails or is null is only decided at runtime, so the keys
+		// are unknown if the first argument that does not fail statically is unknown.
+		for _, arg := range expr.Args {
+			if expr.Name == "try" && t.fails(arg) {
+				continue
+			}
+			return t.resolve(arg)
+		}
+		return nil, false, nil
+
+	case "lookup":
+		if len(expr.Args) < 2 {
+			return nil, false, nil
+		}
+
+		key, ok, err := t.evaluateKey(expr.Args[1])
+		if err != nil || !ok {
+			return nil, false, err
+		}
+		elem, ok, err := t.element(expr.Args[0], key)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			return t.resolve(elem)
+		}
+		if len(expr.Args) > 2 {
+			return t.resolve(expr.Args[2])
+		}
+		return nil, false, nil
+
+	case "tomap":
+		if len(expr.Args) == 1 {
+			return t.resolve(expr.Args[0])
+		}
+	}
+
+	return t.evaluate(expr)
+}
+
+// resolveReference resolves the expression referenced by the traversal, guarding against cycles.
+func (t *tagKeyResolver) resolveReference(traversal hcl.Traversal, expr hcl.Expression) ([]string, bool, error) {
+	name := traversalString(traversal)
+	if t.resolving[name] {
+		return nil, false, nil
+	}
+	t.resolving[name] = true
+	defer delete(t.resolving, name)
+
+	return t.resolve(expr)
+}
+
+// lookup returns the expression referenced by a `local` or `var` traversal, following the
+// attributes and indexes of the traversal through object expressions, e.g. `local.tags.extra`.
+func (t *tagKeyResolver) lookup(traversal hcl.Traversal) (hcl.Expression, bool, error) {
+	if len(traversal) < 2 {
+		return nil, false, nil
+	}
+	step, ok := traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return nil, false, nil
+	}
+
+	var expr hcl.Expression
+	switch traversal.RootName() {
+	case "local":
+		expr, ok = t.locals[step.Name]
+	case "var":
+		expr, ok = t.variables[step.Name]
+	}
+	if !ok {
+		return nil, false, nil
+	}
+
+	for _, step := range traversal[2:] {
+		var key string
+		switch step := step.(type) {
+		case hcl.TraverseAttr:
+			key = step.Name
+		case hcl.TraverseIndex:
+			if step.Key.Type() != cty.String {
+				return nil, false, nil
+			}
+			key = step.Key.AsString()
+		default:
+			return nil, false, nil
+		}
+
+		elem, ok, err := t.element(expr, key)
+		if err != nil || !ok {
+			return nil, false, err
+		}
+		expr = elem
+	}
+
+	return expr, true, nil
+}
+
+// fails returns whether the expression refers to an attribute missing from the object expression
+// of a local value or a variable default, e.g. `local.tag_sets.missing`, so it fails for certain.
+func (t *tagKeyResolver) fails(expr hcl.Expression) bool {
+	traversalExpr, ok := expr.(*hclsyntax.ScopeTraversalExpr)
+	if !ok || len(traversalExpr.Traversal) < 3 {
+		return false
+	}
+
+	// The local value or the variable itself, which is to contain the rest of the traversal
+	expr, ok, err := t.lookup(traversalExpr.Traversal[:2])
+	if err != nil || !ok {
+		return false
+	}
+
+	for _, step := range traversalExpr.Traversal[2:] {
+		attr, ok := step.(hcl.TraverseAttr)
+		if !ok {
+			return false
+		}
+		for paren, ok := expr.(*hclsyntax.ParenthesesExpr); ok; paren, ok = expr.(*hclsyntax.ParenthesesExpr) {
+			expr = paren.Expression
+		}
+		objExpr, ok := expr.(*hclsyntax.ObjectConsExpr)
+		if !ok {
+			return false
+		}
+
+		var elem hcl.Expression
+		for _, item := range objExpr.Items {
+			key, ok := tagEntryKey(item.KeyExpr)
+			if !ok {
+				return false
+			}
+			if key == attr.Name {
+				elem = item.ValueExpr
+			}
+		}
+		if elem == nil {
+			return true
+		}
+		expr = elem
+	}
+
+	return false
+}
+
+// index returns the expression of the element of an index expression, e.g. `local.tag_sets[var.env]`.
+func (t *tagKeyResolver) index(expr *hclsyntax.IndexExpr) (hcl.Expression, bool, error) {
+	key, ok, err := t.evaluateKey(expr.Key)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return t.element(expr.Collection, key)
+}
+
+// element returns the expression of the element of an object expression by its key,
+// following references to local values and variable defaults.
+func (t *tagKeyResolver) element(expr hcl.Expression, key string) (hcl.Expression, bool, error) {
+	switch expr := expr.(type) {
+	case *hclsyntax.ParenthesesExpr:
+		return t.element(expr.Expression, key)
+
+	case *hclsyntax.ObjectConsExpr:
+		for _, item := range expr.Items {
+			if extractKeyName(item.KeyExpr) == key {
+				return item.ValueExpr, true, nil
+			}
+		}
+
+	case *hclsyntax.ScopeTraversalExpr:
+		name := traversalString(expr.Traversal)
+		if t.resolving[name] {
+			return nil, false, nil
+		}
+		t.resolving[name] = true
+		defer delete(t.resolving, name)
+
+		elem, ok, err := t.lookup(expr.Traversal)
+		if err != nil || !ok {
+			return nil, false, err
+		}
+		return t.element(elem, key)
+
+	case *hclsyntax.IndexExpr:
+		elem, ok, err := t.index(expr)
+		if err != nil || !ok {
+			return nil, false, err
+		}
+		return t.element(elem, key)
+	}
+
+	return nil, false, nil
+}
+
+// evaluateKey evaluates an object key or an index to a string, if it can be evaluated statically.
+func (t *tagKeyResolver) evaluateKey(expr hcl.Expression) (string, bool, error) {
+	if !isStaticallyEvaluable(expr) {
+		return "", false, nil
+	}
+
+	var key string
+	var ok bool
+	err := t.runner.EvaluateExpr(expr, func(val string) error {
+		key = val
+		ok = true
+		return nil
+	}, nil)
+	return key, ok, err
+}
+
+// evaluate returns the keys of the evaluated value of the expression. The keys are unknown
+// if the value is unknown or cannot be evaluated statically.
+func (t *tagKeyResolver) evaluate(expr hcl.Expression) ([]string, bool, error) {
+	if !isStaticallyEvaluable(expr) {
+		return nil, false, nil
+	}
+
+	var keys []string
+	var known bool
+	err := t.runner.EvaluateExpr(expr, func(val cty.Value) error {
+		if !val.IsKnown() || val.IsNull() || !(val.Type().IsObjectType() || val.Type().IsMapType()) {
+			return nil
+		}
+
+		known = true
+		for it := val.ElementIterator(); it.Next(); {
+			k, _ := it.Element()
+			keys = append(keys, k.AsString())
+		}
+		return nil
+	}, nil)
+	return keys, known, err
+}
+
+// isStaticallyEvaluable returns whether the expression only references variables and workspace or path information.
+// Local values are resolved by the resolver instead, and other references such as `module.*` are only known after apply.
+func isStaticallyEvaluable(expr hcl.Expression) bool {
+	for _, traversal := range expr.Variables() {
+		switch traversal.RootName() {
+		case "var", "terraform", "path":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// traversalString returns the dotted name of the attributes of a traversal, e.g. `local.tags`.
+func traversalString(traversal hcl.Traversal) string {
+	names := []string{traversal.RootName()}
+	for _, step := range traversal[1:] {
+		if attr, ok := step.(hcl.TraverseAttr); ok {
+			names = append(names, attr.Name)
+		}
+	}
+	return strings.Join(names, ".")
+}
+
+// taggableResources is the catalogue of resource types supporting tags, embedded for offline use.
+var taggableResources = loadTaggableResources()
+
+//go:embed taggable/*.txt
+var taggableResourcesFS embed.FS
+
+// loadTaggableResources reads the resource types listed in the embedded catalogue, one per line.
+func loadTaggableResources() map[string]bool {
+	files, err := fs.Glob(taggableResourcesFS, "taggable/*.txt")
+	if err != nil {
+		panic(err)
+	}
+
+	resources := make(map[string]bool)
+	for _, file := range files {
+		src, err := taggableResourcesFS.ReadFile(file)
+		if err != nil {
+			panic(err)
+		}
+
+		for _, line := range strings.Split(string(src), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			resources[line] = true
+		}
+	}
+
+	return resources
+}
+
+// getPrefixes merges the configured resource prefixes over the default ones.
+// Attributes omitted in the configuration keep the value of the prefix, or the value of the
+// longest shorter prefix for new prefixes, e.g. `aws_iam_` inherits from `aws_`.
+func (c *terraformRequiredTagsConfig) getPrefixes() (map[string]requiredTagsPrefix, error) {
+	prefixes := make(map[string]requiredTagsPrefix)
+	for name, prefix := range defaultRequiredTagsPrefixes {
+		prefixes[name] = prefix
+	}
+
+	overrides := slices.Clone(c.ResourcePrefixes)
+	slices.SortStableFunc(overrides, func(a, b terraformRequiredTagsPrefixConfig) int {
+		return len(a.Prefix) - len(b.Prefix)
+	})
+
+	for _, override := range overrides {
+		prefix, exists := prefixes[override.Prefix]
+		if !exists {
+			prefix = lookupRequiredTagsPrefix(prefixes, override.Prefix)
+		}
+
+		if override.Attribute != nil {
+			prefix.Attribute = *override.Attribute
+		}
+		if override.RequiredTags != nil {
+			prefix.RequiredTags = override.RequiredTags
+		}
+		if override.KeyPattern != nil {
+			if *override.KeyPattern == "" {
+				prefix.KeyPattern = nil
+			} else {
+				pattern, err := regexp.Compile(*override.KeyPattern)
+				if err != nil {
+					return nil, fmt.Errorf("`%s` is invalid key pattern: %w", *override.KeyPattern, err)
+				}
+				prefix.KeyPattern = pattern
+			}
+		}
+
+		prefixes[override.Prefix] = prefix
+	}
+
+	return prefixes, nil
+}
+
+// validateExcludedResources returns an error if any of the excluded resources is an invalid glob.
+func (c *terraformRequiredTagsConfig) validateExcludedResources() error {
+	for _, excluded := range c.ExcludedResources {
+		if _, err := path.Match(excluded, ""); err != nil {
+			return fmt.Errorf("`%s` is invalid excluded resource pattern: %w", excluded, err)
+		}
+	}
+	return nil
+}
+
+// isExcluded returns whether the resource is excluded, either by its type or by its address
+// `<type>.<name>`, e.g. `aws_iam_*` or `aws_s3_bucket.logs`. Module calls are excluded by
+// their address `module.<name>`.
+func (c *terraformRequiredTagsConfig) isExcluded(resourceType, name string) bool {
+	address := fmt.Sprintf("%s.%s", resourceType, name)
+	for _, excluded := range c.ExcludedResources {
+		target := resourceType
+		if strings.Contains(excluded, ".") {
+			target = address
+		}
+
+		if matched, _ := path.Match(excluded, target); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// getModuleSources returns the tags configurations of the module calls in the configured order.
+// Without any configuration, the `tags` argument of every module call is checked.
+func (c *terraformRequiredTagsConfig) getModuleSources() ([]requiredTagsModuleSource, error) {
+	if len(c.ModuleSources) == 0 {
+		return []requiredTagsModuleSource{{Pattern: regexp.MustCompile(`^.*$`), Attribute: "tags"}}, nil
+	}
+
+	sources := make([]requiredTagsModuleSource, 0, len(c.ModuleSources))
+	for _, config := range c.ModuleSources {
+		pattern, err := compileModuleSourcePattern(config.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		source := requiredTagsModuleSource{
+			Pattern:      pattern,
+			Attribute:    "tags",
+			RequiredTags: config.RequiredTags,
+			Required:     config.Required,
+		}
+		if config.Attribute != nil {
+			source.Attribute = *config.Attribute
+		}
+		sources = append(sources, source)
+	}
+
+	return sources, nil
+}
+
+// compileModuleSourcePattern compiles a module source pattern, either a regular expression
+// enclosed in slashes, e.g. `/^git::/`, or a glob where `*` matches any characters, e.g. `./modules/*`.
+func compileModuleSourcePattern(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return nil, fmt.Errorf("`%s` is invalid module source pattern: %w", pattern, err)
+		}
+		return re, nil
+	}
+
+	glob := regexp.QuoteMeta(pattern)
+	glob = strings.ReplaceAll(glob, `\*`, ".*")
+	glob = strings.ReplaceAll(glob, `\?`, ".")
+	return regexp.MustCompile("^" + glob + "$"), nil
+}
+
+// lookupRequiredTagsModuleSource returns the first tags configuration matching the module source.
+func lookupRequiredTagsModuleSource(sources []requiredTagsModuleSource, source string) (requiredTagsModuleSource, bool) {
+	for _, config := range sources {
+		if config.Pattern.MatchString(source) {
+			return config, true
+		}
+	}
+	return requiredTagsModuleSource{}, false
+}
+
+// lookupRequiredTags returns the tags configuration of the resource type, that is the
+// configuration of its `resource_type` block, if any, over the one of its longest prefix.
+func (c *terraformRequiredTagsConfig) lookupRequiredTags(prefixes map[string]requiredTagsPrefix, resourceType string) requiredTagsPrefix {
+	prefix := lookupRequiredTagsPrefix(prefixes, resourceType)
+	for _, config := range c.ResourceTypes {
+		if config.Type != resourceType {
+			continue
+		}
+
+		if config.Tags != nil {
+			prefix.Tags = config.Tags
+		}
+		if config.RequiredTags != nil {
+			prefix.RequiredTags = config.RequiredTags
+		}
+	}
+	return prefix
+}
+
+// lookupRequiredTagsPrefix returns the tags configuration of the longest prefix of the resource type.
+// Resource types without any matching prefix hold their tags in `tags`.
+func lookupRequiredTagsPrefix(prefixes map[string]requiredTagsPrefix, resourceType string) requiredTagsPrefix {
+	match := requiredTagsPrefix{Attribute: "tags"}
+	matchLen := -1
+	for name, prefix := range prefixes {
+		if strings.HasPrefix(resourceType, name) && len(name) > matchLen {
+			match = prefix
+			matchLen = len(name)
+		}
+	}
+	return match
+}
+
+// tagsAttributeNames returns the sorted names of the attributes holding tags.
+func tagsAttributeNames(prefixes map[string]requiredTagsPrefix) []string {
+	var names []string
+	for _, prefix := range prefixes {
+		if !slices.Contains(names, prefix.Attribute) {
+			names = append(names, prefix.Attribute)
+		}
+	}
+	slices.Sort(names)
+	return names
 }
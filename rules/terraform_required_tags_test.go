@@ -364,6 +364,681 @@ rule "terraform_required_tags" {
 				},
 			},
 		},
+		{
+			Name: "aws resources with tags from `default_tags` of the default and aliased provider configurations.",
+			Content: `
+locals {
+  tags = {
+    my_required_tag = "my_tag"
+  }
+}
+
+provider "aws" {
+  default_tags {
+    tags = local.tags
+  }
+}
+
+provider "aws" {
+  alias = "west"
+}
+
+provider "aws" {
+  alias = "east"
+
+  default_tags {
+    tags = {
+      my_required_tag = "my_tag"
+    }
+  }
+}
+
+resource "aws_instance" "default" {
+  tags = {
+    Name = "default"
+  }
+}
+
+resource "aws_instance" "east" {
+  provider = aws.east
+
+  tags = {
+    Name = "east"
+  }
+}
+
+resource "aws_instance" "west" {
+  provider = aws.west
+
+  tags = {
+    Name = "west"
+  }
+}
+`,
+			Config: testTerraformRequiredTagsConfig,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "aws_instance 'west' is missing required tags: [my_required_tag]",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 45, Column: 10},
+						End:      hcl.Pos{Line: 47, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			Name: "taggable resources without tags or labels attribute.",
+			Content: `
+locals {
+  tags = {
+    my_required_tag = "my_tag"
+  }
+}
+
+resource "aws_instance" "untagged" {
+  ami = "ami-12345678"
+}
+
+resource "google_storage_bucket" "labeled" {
+  labels = {
+    env = "dev"
+  }
+}
+
+resource "google_compute_instance" "unlabeled" {
+  name = "test"
+}
+`,
+			Config: testTerraformRequiredTagsConfig,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "aws_instance 'untagged' is missing the `tags` attribute",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 1},
+						End:      hcl.Pos{Line: 8, Column: 35},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "google_storage_bucket 'labeled' is missing required tags: [my_required_tag]",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 13, Column: 12},
+						End:      hcl.Pos{Line: 15, Column: 4},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "google_compute_instance 'unlabeled' is missing the `labels` attribute",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 18, Column: 1},
+						End:      hcl.Pos{Line: 18, Column: 47},
+					},
+				},
+			},
+		},
+		{
+			Name: "taggable resource without tags attribute, but with provider `default_tags`.",
+			Content: `
+provider "aws" {
+  default_tags {
+    tags = {
+      my_required_tag = "my_tag"
+    }
+  }
+}
+
+resource "aws_s3_bucket" "logs" {
+  bucket = "logs"
+}
+
+locals {
+  tags = {
+    my_required_tag = "my_tag"
+  }
+}
+`,
+			Config: testTerraformRequiredTagsConfig,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "aws_s3_bucket 'logs' is missing required tag: Name",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 10, Column: 1},
+						End:      hcl.Pos{Line: 10, Column: 32},
+					},
+				},
+			},
+		},
+		{
+			Name: "resources with provider-specific tag attributes, required tags and key patterns.",
+			Content: `
+locals {
+  tags = {
+    my_required_tag = "my_tag"
+  }
+}
+
+resource "oci_core_instance" "this" {
+  freeform_tags = {
+    my_required_tag = "my_tag"
+  }
+}
+
+resource "aws_instance" "this" {
+  tags = local.tags
+}
+
+resource "azurerm_resource_group" "this" {
+  tags = {
+    my_required_tag = "my_tag"
+    "cost/center"   = "1234"
+  }
+}
+
+resource "google_storage_bucket" "this" {
+  labels = {
+    my_required_tag = "my_tag"
+    Team            = "core"
+  }
+}
+`,
+			Config: `
+rule "terraform_required_tags" {
+  enabled = true
+  tags    = ["my_required_tag"]
+
+  resource_prefix "oci_" {
+    attribute     = "freeform_tags"
+    required_tags = ["owner"]
+  }
+
+  resource_prefix "aws_" {
+    required_tags = []
+  }
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "oci_core_instance 'this' is missing required tag: owner",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 9, Column: 19},
+						End:      hcl.Pos{Line: 11, Column: 4},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "azurerm_resource_group 'this' has tag key `cost/center` not matching `^[^<>%&\\\\?/]{1,512}$`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 19, Column: 10},
+						End:      hcl.Pos{Line: 22, Column: 4},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "google_storage_bucket 'this' has tag key `Team` not matching `^[\\p{Ll}\\p{Lo}][\\p{Ll}\\p{Lo}\\p{N}_-]{0,62}$`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 26, Column: 12},
+						End:      hcl.Pos{Line: 29, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			Name: "constrained tags with values that are not strings.",
+			Content: `
+locals {
+  tags = {
+    my_required_tag = "my_tag"
+  }
+}
+
+resource "my_resource" "this" {
+  tags = merge(local.tags, {
+    env = ["dev"]
+  })
+}
+`,
+			Config: `
+rule "terraform_required_tags" {
+  enabled = true
+  tags    = ["my_required_tag"]
+
+  tag "env" {
+    values = ["dev"]
+  }
+}
+`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "tag values not matching the allowed values, pattern, non-empty and maximum length constraints.",
+			Content: `
+locals {
+  tags = {
+    my_required_tag = "my_tag"
+    env             = "Production"
+  }
+}
+
+resource "aws_instance" "this" {
+  tags = merge(local.tags, {
+    Name  = "web"
+    owner = ""
+  })
+}
+
+resource "aws_s3_bucket" "this" {
+  tags = {
+    my_required_tag = "my_tag"
+    Name            = var.enabled ? "bucket" : "a-very-long-bucket-name"
+    env             = "prd"
+    cost_center     = "cc-12"
+  }
+}
+
+variable "enabled" {
+  type    = bool
+  default = true
+}
+`,
+			Config: `
+rule "terraform_required_tags" {
+  enabled = true
+  tags    = ["my_required_tag"]
+
+  tag "env" {
+    values = ["dev", "stg", "prd"]
+  }
+
+  tag "owner" {
+    non_empty = true
+  }
+
+  tag "cost_center" {
+    pattern = "^cc-[0-9]{4}$"
+  }
+
+  tag "Name" {
+    max_length = 16
+  }
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "tag `env` has value `Production` not in [dev, stg, prd]",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 5},
+						End:      hcl.Pos{Line: 5, Column: 35},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "tag `owner` must not be empty",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 12, Column: 5},
+						End:      hcl.Pos{Line: 12, Column: 15},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "tag `Name` has value `a-very-long-bucket-name` longer than 16 characters",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 19, Column: 5},
+						End:      hcl.Pos{Line: 19, Column: 73},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "tag `cost_center` has value `cc-12` not matching `^cc-[0-9]{4}$`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 21, Column: 5},
+						End:      hcl.Pos{Line: 21, Column: 30},
+					},
+				},
+			},
+		},
+		{
+			Name: "resources with tags composed from locals, variable defaults, nested function calls and conditionals.",
+			Content: `
+locals {
+  tags = {
+    my_required_tag = "my_tag"
+  }
+
+  common_tags = merge(local.tags, {
+    team = "core"
+  })
+
+  tag_sets = {
+    extra = {
+      my_required_tag = "my_tag"
+    }
+  }
+}
+
+variable "tags" {
+  type = map(string)
+  default = {
+    my_required_tag = "my_tag"
+  }
+}
+
+resource "my_resource" "common" {
+  tags = merge(merge(local.common_tags), { owner = "me" })
+}
+
+resource "my_resource" "nested" {
+  tags = local.tag_sets.extra
+}
+
+resource "my_resource" "variable" {
+  tags = var.tags
+}
+
+resource "my_resource" "lookup" {
+  tags = lookup(local.tag_sets, "missing", {})
+}
+
+resource "my_resource" "try" {
+  tags = try(local.tag_sets.missing, local.tags)
+}
+
+resource "my_resource" "conditional" {
+  tags = var.enabled ? local.tags : {}
+}
+
+resource "my_resource" "module" {
+  tags = module.labels.tags
+}
+
+resource "my_resource" "partial" {
+  tags = merge(module.labels.tags, local.tags)
+}
+`,
+			Config: testTerraformRequiredTagsConfig,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "my_resource 'lookup' is missing required tags: [my_required_tag]",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 38, Column: 10},
+						End:      hcl.Pos{Line: 38, Column: 47},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "my_resource 'conditional' is missing required tags: [my_required_tag]",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 46, Column: 10},
+						End:      hcl.Pos{Line: 46, Column: 39},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "my_resource 'module' has tags that cannot be determined",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 50, Column: 10},
+						End:      hcl.Pos{Line: 50, Column: 28},
+					},
+				},
+			},
+		},
+		{
+			Name: "resources with tags from try and coalesce of variables without default.",
+			Content: `
+locals {
+  tags = {
+    env = "dev"
+  }
+}
+
+variable "tags" {
+  type = map(string)
+}
+
+resource "my_resource" "try" {
+  tags = try(var.tags, {})
+}
+
+resource "my_resource" "coalesce" {
+  tags = coalesce(var.tags, { owner = "me" })
+}
+`,
+			Config: `
+rule "terraform_required_tags" {
+  enabled = true
+  tags    = ["env"]
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "my_resource 'try' has tags that cannot be determined",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 13, Column: 10},
+						End:      hcl.Pos{Line: 13, Column: 27},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "my_resource 'coalesce' has tags that cannot be determined",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 17, Column: 10},
+						End:      hcl.Pos{Line: 17, Column: 46},
+					},
+				},
+			},
+		},
+		{
+			Name: "resource with tags that cannot be determined, ignored by the configuration.",
+			Content: `
+locals {
+  tags = {
+    my_required_tag = "my_tag"
+  }
+}
+
+resource "my_resource" "module" {
+  tags = module.labels.tags
+}
+`,
+			Config: `
+rule "terraform_required_tags" {
+  enabled           = true
+  tags              = ["my_required_tag"]
+  undetermined_tags = "ignore"
+}
+`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "module calls with tags checked by module source pattern.",
+			Content: `
+locals {
+  tags = {
+    my_required_tag = "my_tag"
+  }
+}
+
+module "vpc" {
+  source = "terraform-aws-modules/vpc/aws"
+
+  tags = {
+    env = "dev"
+  }
+}
+
+module "network" {
+  source = "./modules/network"
+
+  labels = merge(local.tags, {
+    env = "dev"
+  })
+}
+
+module "storage" {
+  source = "./modules/storage"
+}
+
+module "dns" {
+  source = "git::https://example.com/dns.git?ref=v1.0.0"
+}
+`,
+			Config: `
+rule "terraform_required_tags" {
+  enabled = true
+  tags    = ["my_required_tag"]
+
+  module "./modules/*" {
+    attribute     = "labels"
+    required_tags = ["owner"]
+    required      = true
+  }
+
+  module "/^terraform-aws-modules//" {}
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "module 'vpc' is missing required tags: [my_required_tag]",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 10},
+						End:      hcl.Pos{Line: 13, Column: 4},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "module 'network' is missing required tag: owner",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 19, Column: 12},
+						End:      hcl.Pos{Line: 21, Column: 5},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "module 'storage' is missing the `labels` argument",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 24, Column: 1},
+						End:      hcl.Pos{Line: 24, Column: 17},
+					},
+				},
+			},
+		},
+		{
+			Name: "resources excluded by glob patterns and addresses, and resource types overriding the required tags.",
+			Content: `
+locals {
+  tags = {
+    my_required_tag = "my_tag"
+  }
+}
+
+resource "aws_iam_role" "this" {
+  tags = {}
+}
+
+resource "aws_s3_bucket" "logs" {
+  tags = {}
+}
+
+resource "aws_s3_bucket" "data" {
+  tags = {}
+}
+
+resource "aws_autoscaling_group" "this" {
+  tags = {
+    owner = "me"
+  }
+}
+
+resource "aws_autoscaling_group_tag" "this" {
+  tags = {
+    my_required_tag = "my_tag"
+    Name            = "this"
+  }
+}
+
+module "legacy" {
+  source = "./modules/legacy"
+
+  tags = {}
+}
+`,
+			Config: `
+rule "terraform_required_tags" {
+  enabled            = true
+  tags               = ["my_required_tag"]
+  excluded_resources = ["aws_iam_*", "aws_s3_bucket.logs", "module.legacy"]
+
+  resource_type "aws_autoscaling_group" {
+    tags = ["owner", "team"]
+  }
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "aws_s3_bucket 'data' is missing required tags: [my_required_tag]",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 17, Column: 10},
+						End:      hcl.Pos{Line: 17, Column: 12},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "aws_s3_bucket 'data' is missing required tag: Name",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 17, Column: 10},
+						End:      hcl.Pos{Line: 17, Column: 12},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "aws_autoscaling_group 'this' is missing required tags: [team]",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 21, Column: 10},
+						End:      hcl.Pos{Line: 23, Column: 4},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "aws_autoscaling_group 'this' is missing required tag: Name",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 21, Column: 10},
+						End:      hcl.Pos{Line: 23, Column: 4},
+					},
+				},
+			},
+		},
 	}
 
 	rule := NewTerraformRequiredTags()
@@ -391,3 +1066,221 @@ rule "terraform_required_tags" {
   excluded_resources = ["my_excluded_resource"]
 }
 `
+
+func Test_TerraformRequiredTags_KeyCase(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+		Fixed    string
+	}{
+		{
+			Name: "tag keys differing from required tags only by case, not matching the key case, or duplicated.",
+			Content: `
+locals {
+  tags = {
+    Env             = "dev"
+    my_required_tag = "my_tag"
+    "CostCenter"    = "cc-1234"
+    Name            = "web"
+    Owner           = "me"
+    owner           = "you"
+  }
+}
+`,
+			Config: `
+rule "terraform_required_tags" {
+  enabled             = true
+  tags                = ["env", "my_required_tag"]
+  key_case            = "snake_case"
+  key_case_exceptions = ["Name"]
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "tag `Env` must be spelled `env`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 5},
+						End:      hcl.Pos{Line: 4, Column: 8},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "tag `CostCenter` must match the snake_case format",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 5},
+						End:      hcl.Pos{Line: 6, Column: 17},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "tag `owner` duplicates tag `Owner`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 9, Column: 5},
+						End:      hcl.Pos{Line: 9, Column: 10},
+					},
+				},
+			},
+			Fixed: `
+locals {
+  tags = {
+    env             = "dev"
+    my_required_tag = "my_tag"
+    "cost_center"   = "cc-1234"
+    Name            = "web"
+    Owner           = "me"
+    owner           = "you"
+  }
+}
+`,
+		},
+		{
+			Name: "lookup table keys are not tag keys, but the default of lookup is.",
+			Content: `
+locals {
+  tags = {
+    env             = "dev"
+    my_required_tag = "my_tag"
+  }
+}
+
+variable "environment" {
+  default = "dev"
+}
+
+resource "my_resource" "this" {
+  tags = merge(local.tags, lookup({
+    Prod = { CostCenter = "cc-1" }
+  }, var.environment, { CostCenter = "cc-0" }))
+}
+`,
+			Config: `
+rule "terraform_required_tags" {
+  enabled  = true
+  tags     = ["env", "my_required_tag"]
+  key_case = "snake_case"
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "tag `CostCenter` must match the snake_case format",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 16, Column: 25},
+						End:      hcl.Pos{Line: 16, Column: 35},
+					},
+				},
+			},
+			Fixed: `
+locals {
+  tags = {
+    env             = "dev"
+    my_required_tag = "my_tag"
+  }
+}
+
+variable "environment" {
+  default = "dev"
+}
+
+resource "my_resource" "this" {
+  tags = merge(local.tags, lookup({
+    Prod = { CostCenter = "cc-1" }
+  }, var.environment, { cost_center = "cc-0" }))
+}
+`,
+		},
+		{
+			Name: "tag keys spelled as tags required only of other resource types.",
+			Content: `
+locals {
+  tags = {
+    env             = "dev"
+    my_required_tag = "my_tag"
+  }
+}
+
+resource "google_compute_instance" "web" {
+  labels = merge(local.tags, {
+    name = "web"
+  })
+}
+
+resource "aws_instance" "web" {
+  tags = merge(local.tags, {
+    name = "web"
+  })
+}
+`,
+			Config: `
+rule "terraform_required_tags" {
+  enabled = true
+  tags    = ["env", "my_required_tag"]
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "tag `name` must be spelled `Name`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 17, Column: 5},
+						End:      hcl.Pos{Line: 17, Column: 9},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredTags(),
+					Message: "aws_instance 'web' is missing required tag: Name",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 16, Column: 10},
+						End:      hcl.Pos{Line: 18, Column: 5},
+					},
+				},
+			},
+			Fixed: `
+locals {
+  tags = {
+    env             = "dev"
+    my_required_tag = "my_tag"
+  }
+}
+
+resource "google_compute_instance" "web" {
+  labels = merge(local.tags, {
+    name = "web"
+  })
+}
+
+resource "aws_instance" "web" {
+  tags = merge(local.tags, {
+    Name = "web"
+  })
+}
+`,
+		},
+	}
+
+	rule := NewTerraformRequiredTags()
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{
+				"main.tf":     test.Content,
+				".tflint.hcl": test.Config,
+			})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, test.Expected, runner.Issues)
+			helper.AssertChanges(t, map[string]string{"main.tf": test.Fixed}, runner.Changes())
+		})
+	}
+}
@@ -1,13 +1,19 @@
 package rules
 
 import (
+	"bytes"
 	"fmt"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
 )
 
 // TerraformRequiredVariables checks whether variables have a type checked
@@ -16,7 +22,72 @@ type TerraformRequiredVariables struct {
 }
 
 type terraformRequiredVariablesConfig struct {
-	RequiredVars []string `hclext:"required_vars,optional"`
+	Preset         string                               `hclext:"preset,optional"`
+	RequiredVars   []string                             `hclext:"required_vars,optional"`
+	VariablesFile  string                               `hclext:"variables_file,optional"`
+	CheckUsage     bool                                 `hclext:"check_usage,optional"`
+	CredentialVars []string                             `hclext:"credential_vars,optional"`
+	Variables      []terraformRequiredVariablesContract `hclext:"variable,block"`
+}
+
+// terraformRequiredVariablesContract declares the expected attributes of a variable.
+// Omitted attributes are not checked.
+type terraformRequiredVariablesContract struct {
+	Name               string  `hclext:"name,label"`
+	Type               *string `hclext:"type,optional"`
+	Sensitive          *bool   `hclext:"sensitive,optional"`
+	Nullable           *bool   `hclext:"nullable,optional"`
+	ForbidDefault      *bool   `hclext:"forbid_default,optional"`
+	RequireDescription *bool   `hclext:"require_description,optional"`
+	// UsedIn lists the types of the top-level blocks that must reference the variable.
+	UsedIn []string `hclext:"used_in,optional"`
+	// Description is only used for the variable generated by the autofix.
+	Description *string `hclext:"description,optional"`
+}
+
+// terraformRequiredVariablesPreset is a named set of required variables and their contracts.
+type terraformRequiredVariablesPreset struct {
+	RequiredVars   []string
+	CredentialVars []string
+	Contracts      []terraformRequiredVariablesContract
+}
+
+// requiredVariablesPresets are the presets according to myklst Terraform standardization.
+// Child modules receive their credentials and metadata from the calling stack, while
+// root stacks only receive the credentials.
+var requiredVariablesPresets = map[string]terraformRequiredVariablesPreset{
+	"child_module": {
+		RequiredVars:   []string{"cloud_creds", "module_info", "module_tmpl"},
+		CredentialVars: []string{"cloud_creds"},
+		Contracts: []terraformRequiredVariablesContract{
+			{Name: "cloud_creds", Sensitive: boolPtr(true), Description: stringPtr("Credentials of the cloud provider.")},
+			{Name: "module_info", Description: stringPtr("Information of the module.")},
+			{Name: "module_tmpl", Description: stringPtr("Template of the module.")},
+		},
+	},
+	"root_module": {
+		RequiredVars:   []string{"cloud_creds"},
+		CredentialVars: []string{"cloud_creds"},
+		Contracts: []terraformRequiredVariablesContract{
+			{Name: "cloud_creds", Sensitive: boolPtr(true), Description: stringPtr("Credentials of the cloud provider.")},
+		},
+	},
+	"none": {},
+}
+
+// variableUsageSites are the types of the top-level blocks that can reference variables, with their label names.
+var variableUsageSites = map[string][]string{
+	"check":     {"name"},
+	"data":      {"type", "name"},
+	"import":    nil,
+	"locals":    nil,
+	"module":    {"name"},
+	"moved":     nil,
+	"output":    {"name"},
+	"provider":  {"name"},
+	"removed":   nil,
+	"resource":  {"type", "name"},
+	"terraform": nil,
 }
 
 // NewTerraformRequiredVariables returns a new rule
@@ -45,15 +116,28 @@ func (r *TerraformRequiredVariables) Link() string {
 }
 
 // Check checks whether required_vars have been declared as variables within the module
+// and whether the declared variables match their contracts
 func (r *TerraformRequiredVariables) Check(runner tflint.Runner) error {
-	config := &terraformRequiredVariablesConfig{}
+	config := &terraformRequiredVariablesConfig{
+		Preset:        "child_module",
+		VariablesFile: "variables.tf",
+	}
 
 	if err := runner.DecodeRuleConfig(r.Name(), config); err != nil {
 		return err
 	}
 
-	// Required variables according to myklst Terraform standardization
-	config.RequiredVars = append(config.RequiredVars, "cloud_creds", "module_info", "module_tmpl")
+	preset, exists := requiredVariablesPresets[config.Preset]
+	if !exists {
+		return fmt.Errorf("`%s` is unsupported preset", config.Preset)
+	}
+	config.RequiredVars = append(config.RequiredVars, preset.RequiredVars...)
+	config.CredentialVars = append(config.CredentialVars, preset.CredentialVars...)
+
+	contracts, err := config.getContracts(preset)
+	if err != nil {
+		return err
+	}
 
 	variables, err := runner.GetModuleContent(&hclext.BodySchema{
 		Blocks: []hclext.BlockSchema{
@@ -62,9 +146,11 @@ func (r *TerraformRequiredVariables) Check(runner tflint.Runner) error {
 				LabelNames: []string{"name"},
 				Body: &hclext.BodySchema{
 					Attributes: []hclext.AttributeSchema{
-						{
-							Name: "sensitive",
-						},
+						{Name: "type"},
+						{Name: "sensitive"},
+						{Name: "nullable"},
+						{Name: "default"},
+						{Name: "description"},
 					},
 				},
 			},
@@ -85,60 +171,676 @@ func (r *TerraformRequiredVariables) Check(runner tflint.Runner) error {
 	for _, requiredVar := range config.RequiredVars {
 		if _, exists := declaredVars[requiredVar]; !exists {
 			missingVars = append(missingVars, requiredVar)
+			// Variables listed in both required_vars and the preset are reported once.
+			declaredVars[requiredVar] = true
 		}
 	}
 
 	if len(missingVars) > 0 {
-		err := runner.EmitIssue(
+		if err := r.emitMissingVariables(runner, config.VariablesFile, missingVars, contracts); err != nil {
+			return err
+		}
+	}
+
+	for _, variable := range variables.Blocks {
+		contract, exists := contracts[variable.Labels[0]]
+		if !exists {
+			continue
+		}
+
+		if err := r.checkContract(runner, variable, contract); err != nil {
+			return err
+		}
+	}
+
+	credentialVars := make(map[string]bool)
+	for _, credentialVar := range config.CredentialVars {
+		credentialVars[credentialVar] = true
+	}
+
+	for _, variable := range variables.Blocks {
+		// The default is already reported by the contract if it forbids one.
+		contract, exists := contracts[variable.Labels[0]]
+		forbidDefault := exists && contract.ForbidDefault != nil && *contract.ForbidDefault
+
+		if err := r.checkSensitive(runner, variable, credentialVars[variable.Labels[0]], forbidDefault); err != nil {
+			return err
+		}
+	}
+
+	references, err := variableReferences(runner)
+	if err != nil {
+		return err
+	}
+
+	requiredVars := make(map[string]bool)
+	for _, requiredVar := range config.RequiredVars {
+		requiredVars[requiredVar] = true
+	}
+
+	for _, variable := range variables.Blocks {
+		name := variable.Labels[0]
+
+		var usedIn []string
+		if contract, exists := contracts[name]; exists {
+			usedIn = contract.UsedIn
+		}
+		if len(usedIn) == 0 && !(config.CheckUsage && requiredVars[name]) {
+			continue
+		}
+
+		if err := r.checkUsage(runner, variable, references[name], usedIn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkUsage reports the variable if it is not referenced, or not referenced from
+// any of the expected types of top-level blocks.
+func (r *TerraformRequiredVariables) checkUsage(runner tflint.Runner, variable *hclext.Block, sites map[string]bool, usedIn []string) error {
+	name := variable.Labels[0]
+
+	if len(sites) == 0 {
+		return runner.EmitIssue(
 			r,
-			fmt.Sprintf("required variable(s) not declared: %s", strings.Join(missingVars, ", ")),
+			fmt.Sprintf("variable `%s` is declared but not referenced", name),
+			variable.DefRange,
+		)
+	}
+
+	if len(usedIn) == 0 {
+		return nil
+	}
+
+	expected := make([]string, len(usedIn))
+	for i, site := range usedIn {
+		if sites[site] {
+			return nil
+		}
+		expected[i] = fmt.Sprintf("`%s`", site)
+	}
+
+	return runner.EmitIssue(
+		r,
+		fmt.Sprintf("variable `%s` is not referenced from any %s block", name, strings.Join(expected, " or ")),
+		variable.DefRange,
+	)
+}
+
+// variableReferences returns the types of the top-level blocks referencing each variable.
+// References within variable blocks, e.g. in validation rules, are ignored.
+func variableReferences(runner tflint.Runner) (map[string]map[string]bool, error) {
+	files, err := runner.GetFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	references := make(map[string]map[string]bool)
+	for _, file := range files {
+		if body, ok := file.Body.(*hclsyntax.Body); ok {
+			for _, block := range body.Blocks {
+				if block.Type == "variable" {
+					continue
+				}
+				collectVariableReferences(block.Body, block.Type, references)
+			}
+			continue
+		}
+
+		// In the JSON syntax, nested blocks are object values of the attributes of the top-level blocks,
+		// whose variables include the references of the nested blocks.
+		schema := &hcl.BodySchema{}
+		for site, labels := range variableUsageSites {
+			schema.Blocks = append(schema.Blocks, hcl.BlockHeaderSchema{Type: site, LabelNames: labels})
+		}
+		content, _, diags := file.Body.PartialContent(schema)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		for _, block := range content.Blocks {
+			attrs, diags := block.Body.JustAttributes()
+			if diags.HasErrors() {
+				return nil, diags
+			}
+			for _, attr := range attrs {
+				addVariableReferences(attr.Expr, block.Type, references)
+			}
+		}
+	}
+
+	return references, nil
+}
+
+// collectVariableReferences records the variables referenced in the body and its nested blocks.
+func collectVariableReferences(body *hclsyntax.Body, site string, references map[string]map[string]bool) {
+	for _, attr := range body.Attributes {
+		addVariableReferences(attr.Expr, site, references)
+	}
+
+	for _, block := range body.Blocks {
+		collectVariableReferences(block.Body, site, references)
+	}
+}
+
+// addVariableReferences records the variables referenced in the expression.
+func addVariableReferences(expr hcl.Expression, site string, references map[string]map[string]bool) {
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() != "var" || len(traversal) < 2 {
+			continue
+		}
+
+		step, ok := traversal[1].(hcl.TraverseAttr)
+		if !ok {
+			continue
+		}
+
+		if references[step.Name] == nil {
+			references[step.Name] = make(map[string]bool)
+		}
+		references[step.Name][site] = true
+	}
+}
+
+// getContracts merges the configured variable contracts over the ones of the preset.
+// Attributes omitted in the configuration keep the value of the preset.
+func (c *terraformRequiredVariablesConfig) getContracts(preset terraformRequiredVariablesPreset) (map[string]*terraformRequiredVariablesContract, error) {
+	contracts := make(map[string]*terraformRequiredVariablesContract)
+	for _, contract := range preset.Contracts {
+		contract := contract
+		contracts[contract.Name] = &contract
+	}
+
+	for _, override := range c.Variables {
+		if override.Type != nil {
+			if _, err := parseVariableContractType(*override.Type); err != nil {
+				return nil, fmt.Errorf("`%s` is invalid type for variable `%s`: %w", *override.Type, override.Name, err)
+			}
+		}
+
+		contract, exists := contracts[override.Name]
+		if !exists {
+			contract = &terraformRequiredVariablesContract{Name: override.Name}
+			contracts[override.Name] = contract
+		}
+
+		if override.Type != nil {
+			contract.Type = override.Type
+		}
+		if override.Sensitive != nil {
+			contract.Sensitive = override.Sensitive
+		}
+		if override.Nullable != nil {
+			contract.Nullable = override.Nullable
+		}
+		if override.ForbidDefault != nil {
+			contract.ForbidDefault = override.ForbidDefault
+		}
+		if override.RequireDescription != nil {
+			contract.RequireDescription = override.RequireDescription
+		}
+		if override.Description != nil {
+			contract.Description = override.Description
+		}
+		if override.UsedIn != nil {
+			for _, site := range override.UsedIn {
+				if _, exists := variableUsageSites[site]; !exists {
+					return nil, fmt.Errorf("`%s` is unsupported usage site for variable `%s`", site, override.Name)
+				}
+			}
+			contract.UsedIn = override.UsedIn
+		}
+	}
+
+	return contracts, nil
+}
+
+// emitMissingVariables reports the missing variables in the variables file, with a fix
+// appending a variable block for each of them according to their contract.
+// If the module has no such file, the issue is not anchored to any file and cannot be fixed.
+func (r *TerraformRequiredVariables) emitMissingVariables(runner tflint.Runner, variablesFile string, missingVars []string, contracts map[string]*terraformRequiredVariablesContract) error {
+	message := fmt.Sprintf("required variable(s) not declared: %s", strings.Join(missingVars, ", "))
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		if filename == variablesFile || filepath.Base(filename) == variablesFile {
+			filenames = append(filenames, filename)
+		}
+	}
+	sort.Strings(filenames)
+
+	if len(filenames) == 0 {
+		return runner.EmitIssue(
+			r,
+			message,
 			hcl.Range{
 				Start: hcl.Pos{Line: 1, Column: 1},
 				End:   hcl.Pos{Line: 1, Column: 1},
 			},
 		)
-		if err != nil {
+	}
+
+	filename := filenames[0]
+	file := files[filename]
+
+	return runner.EmitIssueWithFix(
+		r,
+		message,
+		hcl.Range{
+			Filename: filename,
+			Start:    hcl.InitialPos,
+			End:      hcl.InitialPos,
+		},
+		func(f tflint.Fixer) error {
+			if _, ok := file.Body.(*hclsyntax.Body); !ok {
+				return tflint.ErrFixNotSupported
+			}
+
+			var stubs strings.Builder
+			if len(bytes.TrimSpace(file.Bytes)) > 0 {
+				if !bytes.HasSuffix(file.Bytes, []byte("\n")) {
+					stubs.WriteString("\n")
+				}
+				stubs.WriteString("\n")
+			}
+			for i, name := range missingVars {
+				if i > 0 {
+					stubs.WriteString("\n")
+				}
+				stubs.WriteString(variableStub(f, name, contracts[name]))
+			}
+
+			return f.InsertTextAfter(endOfFileRange(filename, file.Bytes), stubs.String())
+		},
+	)
+}
+
+// variableStub returns a variable block declaring the attributes of its contract.
+func variableStub(f tflint.Fixer, name string, contract *terraformRequiredVariablesContract) string {
+	var stub strings.Builder
+	fmt.Fprintf(&stub, "variable %s {\n", f.ValueText(cty.StringVal(name)))
+
+	if contract != nil {
+		if contract.Description != nil {
+			fmt.Fprintf(&stub, "  description = %s\n", f.ValueText(cty.StringVal(*contract.Description)))
+		}
+		if contract.Type != nil {
+			fmt.Fprintf(&stub, "  type = %s\n", *contract.Type)
+		}
+		if contract.Sensitive != nil && *contract.Sensitive {
+			stub.WriteString("  sensitive = true\n")
+		}
+		if contract.Nullable != nil && !*contract.Nullable {
+			stub.WriteString("  nullable = false\n")
+		}
+	}
+
+	stub.WriteString("}\n")
+	return stub.String()
+}
+
+// endOfFileRange returns the empty range at the end of the file.
+func endOfFileRange(filename string, src []byte) hcl.Range {
+	pos := hcl.Pos{
+		Line:   bytes.Count(src, []byte("\n")) + 1,
+		Column: len(src) - bytes.LastIndexByte(src, '\n'),
+		Byte:   len(src),
+	}
+	return hcl.Range{Filename: filename, Start: pos, End: pos}
+}
+
+// parseVariableContractType parses the type expression of a variable contract.
+func parseVariableContractType(src string) (cty.Type, error) {
+	expr, diags := hclsyntax.ParseExpression([]byte(src), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return cty.NilType, diags
+	}
+
+	ty, _, diags := typeexpr.TypeConstraintWithDefaults(expr)
+	if diags.HasErrors() {
+		return cty.NilType, diags
+	}
+
+	return ty, nil
+}
+
+// checkContract reports every attribute of the variable that does not match the contract.
+func (r *TerraformRequiredVariables) checkContract(runner tflint.Runner, variable *hclext.Block, contract *terraformRequiredVariablesContract) error {
+	name := variable.Labels[0]
+
+	if contract.Type != nil {
+		if err := r.checkType(runner, variable, *contract.Type); err != nil {
 			return err
 		}
 	}
 
-	// Check for "cloud_creds" variable and its "sensitive" attribute
-	for _, variable := range variables.Blocks {
-		// Skip this check if the variable name matches any of the string in ignore_vars.
-		if variable.Labels[0] != "cloud_creds" {
+	if contract.Sensitive != nil {
+		if err := r.checkBoolAttribute(runner, variable, "sensitive", *contract.Sensitive, false); err != nil {
+			return err
+		}
+	}
+
+	if contract.Nullable != nil {
+		if err := r.checkBoolAttribute(runner, variable, "nullable", *contract.Nullable, true); err != nil {
+			return err
+		}
+	}
+
+	if contract.ForbidDefault != nil && *contract.ForbidDefault {
+		if defaultAttr, exists := variable.Body.Attributes["default"]; exists {
+			err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("variable `%s` must not have the `default` attribute", name),
+				defaultAttr.Range,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if contract.RequireDescription != nil && *contract.RequireDescription {
+		descriptionAttr, exists := variable.Body.Attributes["description"]
+		if !exists {
+			return r.emitMissingAttribute(runner, variable, "description")
+		}
+
+		description, diags := descriptionAttr.Expr.Value(nil)
+		if !diags.HasErrors() && description.Type() == cty.String && description.IsKnown() && !description.IsNull() && strings.TrimSpace(description.AsString()) == "" {
+			return runner.EmitIssue(
+				r,
+				fmt.Sprintf("variable `%s` must have a non-empty `description` attribute", name),
+				descriptionAttr.Range,
+			)
+		}
+	}
+
+	return nil
+}
+
+// checkType reports the type of the variable if it does not match the expected type.
+// When both types are objects, every missing, unexpected or mistyped attribute is
+// reported instead of the whole type.
+func (r *TerraformRequiredVariables) checkType(runner tflint.Runner, variable *hclext.Block, typeSrc string) error {
+	typeAttr, exists := variable.Body.Attributes["type"]
+	if !exists {
+		return r.emitMissingAttribute(runner, variable, "type")
+	}
+
+	want, err := parseVariableContractType(typeSrc)
+	if err != nil {
+		return err
+	}
+
+	if expr, ok := typeAttr.Expr.(hclsyntax.Expression); ok && want.IsObjectType() {
+		if _, ok := unwrapToObjectConsExpr(unwrapParenthesesExpr(expr)); ok {
+			return r.checkObjectShape(runner, variable.Labels[0], variable.Labels[0], expr, want)
+		}
+	}
+
+	got, _, diags := typeexpr.TypeConstraintWithDefaults(typeAttr.Expr)
+	if diags.HasErrors() || !got.Equals(want) {
+		return runner.EmitIssue(
+			r,
+			fmt.Sprintf("variable `%s` must have `type = %s` attribute defined", variable.Labels[0], typeSrc),
+			typeAttr.Range,
+		)
+	}
+
+	return nil
+}
+
+// checkObjectShape compares the attributes of the object type expression at path with
+// the expected object type, recursing into nested objects.
+func (r *TerraformRequiredVariables) checkObjectShape(runner tflint.Runner, name string, path string, expr hclsyntax.Expression, want cty.Type) error {
+	expr = unwrapParenthesesExpr(expr)
+
+	objExpr, ok := unwrapToObjectConsExpr(expr)
+	if !ok || !want.IsObjectType() {
+		got, _, diags := typeexpr.TypeConstraintWithDefaults(expr)
+		if diags.HasErrors() || !got.Equals(want) {
+			return runner.EmitIssue(
+				r,
+				fmt.Sprintf("variable `%s` attribute `%s` must have type `%s`", name, path, typeConstraintString(want)),
+				expr.Range(),
+			)
+		}
+		return nil
+	}
+
+	declared := make(map[string]bool)
+	for _, item := range objExpr.Items {
+		fieldName := typeAttributeName(item.KeyExpr)
+		if fieldName == "" {
 			continue
 		}
+		declared[fieldName] = true
+		fieldPath := fmt.Sprintf("%s.%s", path, fieldName)
 
-		sensitiveAttr, sensitiveExist := variable.Body.Attributes["sensitive"]
-		if !sensitiveExist {
+		if !want.HasAttribute(fieldName) {
 			err := runner.EmitIssue(
 				r,
-				fmt.Sprintf("variable `%s` is missing the `sensitive` attribute", variable.Labels[0]),
-				variable.DefRange,
+				fmt.Sprintf("variable `%s` has unexpected attribute `%s`", name, fieldPath),
+				hcl.RangeBetween(item.KeyExpr.Range(), item.ValueExpr.Range()),
 			)
 			if err != nil {
 				return err
 			}
+			continue
+		}
+
+		wantField := want.AttributeType(fieldName)
+		valueExpr, optional := unwrapOptionalTypeExpr(item.ValueExpr)
+		if optional != want.AttributeOptional(fieldName) {
+			wantString := typeConstraintString(wantField)
+			if want.AttributeOptional(fieldName) {
+				wantString = fmt.Sprintf("optional(%s)", wantString)
+			}
 
+			err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("variable `%s` attribute `%s` must have type `%s`", name, fieldPath, wantString),
+				item.ValueExpr.Range(),
+			)
+			if err != nil {
+				return err
+			}
 			continue
 		}
 
-		sensitiveValue, ok := sensitiveAttr.Expr.(*hclsyntax.LiteralValueExpr)
-		if !ok {
+		if err := r.checkObjectShape(runner, name, fieldPath, valueExpr, wantField); err != nil {
+			return err
+		}
+	}
+
+	fieldNames := make([]string, 0, len(want.AttributeTypes()))
+	for fieldName := range want.AttributeTypes() {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	for _, fieldName := range fieldNames {
+		if declared[fieldName] {
 			continue
 		}
 
-		if !sensitiveValue.Val.True() {
+		err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("variable `%s` is missing attribute `%s.%s`", name, path, fieldName),
+			expr.Range(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unwrapParenthesesExpr returns the expression enclosed in any number of parentheses.
+func unwrapParenthesesExpr(expr hclsyntax.Expression) hclsyntax.Expression {
+	for {
+		parens, ok := expr.(*hclsyntax.ParenthesesExpr)
+		if !ok {
+			return expr
+		}
+		expr = parens.Expression
+	}
+}
+
+// unwrapOptionalTypeExpr returns the type of an optional(type[, default]) attribute
+// type expression, and whether the attribute is optional.
+func unwrapOptionalTypeExpr(expr hclsyntax.Expression) (hclsyntax.Expression, bool) {
+	call, ok := unwrapParenthesesExpr(expr).(*hclsyntax.FunctionCallExpr)
+	if !ok || call.Name != "optional" || len(call.Args) == 0 {
+		return expr, false
+	}
+	return call.Args[0], true
+}
+
+// typeConstraintString renders a type in the type expression syntax of Terraform.
+func typeConstraintString(ty cty.Type) string {
+	switch {
+	case ty.IsListType():
+		return fmt.Sprintf("list(%s)", typeConstraintString(ty.ElementType()))
+	case ty.IsSetType():
+		return fmt.Sprintf("set(%s)", typeConstraintString(ty.ElementType()))
+	case ty.IsMapType():
+		return fmt.Sprintf("map(%s)", typeConstraintString(ty.ElementType()))
+
+	case ty.IsTupleType():
+		elems := make([]string, len(ty.TupleElementTypes()))
+		for i, elem := range ty.TupleElementTypes() {
+			elems[i] = typeConstraintString(elem)
+		}
+		return fmt.Sprintf("tuple([%s])", strings.Join(elems, ", "))
+
+	case ty.IsObjectType():
+		names := make([]string, 0, len(ty.AttributeTypes()))
+		for name := range ty.AttributeTypes() {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fields := make([]string, len(names))
+		for i, name := range names {
+			key := name
+			if !hclsyntax.ValidIdentifier(name) {
+				key = fmt.Sprintf("%q", name)
+			}
+			if ty.AttributeOptional(name) {
+				fields[i] = fmt.Sprintf("%s = optional(%s)", key, typeConstraintString(ty.AttributeType(name)))
+			} else {
+				fields[i] = fmt.Sprintf("%s = %s", key, typeConstraintString(ty.AttributeType(name)))
+			}
+		}
+		return fmt.Sprintf("object({ %s })", strings.Join(fields, ", "))
+	}
+
+	return typeexpr.TypeString(ty)
+}
+
+// checkBoolAttribute reports a bool attribute of the variable that does not match
+// the expected value. A missing attribute is reported unless the expected value
+// matches the Terraform default. Non-literal values are not checked.
+func (r *TerraformRequiredVariables) checkBoolAttribute(runner tflint.Runner, variable *hclext.Block, name string, want bool, defaultValue bool) error {
+	attr, exists := variable.Body.Attributes[name]
+	if !exists {
+		if want == defaultValue {
+			return nil
+		}
+		return r.emitMissingAttribute(runner, variable, name)
+	}
+
+	value, ok := literalBool(attr.Expr)
+	if !ok || value == want {
+		return nil
+	}
+
+	return runner.EmitIssue(
+		r,
+		fmt.Sprintf("variable `%s` must have `%s = %t` attribute defined", variable.Labels[0], name, want),
+		attr.Range,
+	)
+}
+
+// checkSensitive reports a non-literal `sensitive` attribute, and the default value of a
+// variable that is sensitive or holds credentials. Constant expressions such as `!false`
+// are also reported since Terraform only accepts literal values in practice.
+func (r *TerraformRequiredVariables) checkSensitive(runner tflint.Runner, variable *hclext.Block, credential bool, defaultReported bool) error {
+	name := variable.Labels[0]
+	sensitive := credential
+
+	if sensitiveAttr, exists := variable.Body.Attributes["sensitive"]; exists {
+		value, ok := literalBool(sensitiveAttr.Expr)
+		if _, literal := sensitiveAttr.Expr.(*hclsyntax.LiteralValueExpr); !literal && isNativeSyntax(sensitiveAttr.Expr) {
+			ok = false
+		}
+		if !ok {
 			err := runner.EmitIssue(
 				r,
-				fmt.Sprintf("variable `%s` must have `sensitive = true` attribute defined", variable.Labels[0]),
+				fmt.Sprintf("variable `%s` must have a literal `sensitive` value", name),
 				sensitiveAttr.Range,
 			)
 			if err != nil {
 				return err
 			}
 		}
+		sensitive = sensitive || value
 	}
 
-	return nil
+	defaultAttr, exists := variable.Body.Attributes["default"]
+	if !sensitive || !exists || defaultReported {
+		return nil
+	}
+
+	return runner.EmitIssue(
+		r,
+		fmt.Sprintf("variable `%s` holds sensitive values and must not have the `default` attribute", name),
+		defaultAttr.Range,
+	)
+}
+
+// isNativeSyntax returns whether the expression is written in the native syntax rather than JSON.
+func isNativeSyntax(expr hcl.Expression) bool {
+	_, ok := expr.(hclsyntax.Expression)
+	return ok
+}
+
+// literalBool returns the value of an expression that evaluates to a bool without any context.
+func literalBool(expr hcl.Expression) (bool, bool) {
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return false, false
+	}
+
+	value, err := convert.Convert(value, cty.Bool)
+	if err != nil || !value.IsKnown() || value.IsNull() {
+		return false, false
+	}
+
+	return value.True(), true
+}
+
+func (r *TerraformRequiredVariables) emitMissingAttribute(runner tflint.Runner, variable *hclext.Block, name string) error {
+	return runner.EmitIssue(
+		r,
+		fmt.Sprintf("variable `%s` is missing the `%s` attribute", variable.Labels[0], name),
+		variable.DefRange,
+	)
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func stringPtr(s string) *string {
+	return &s
 }
@@ -223,6 +223,647 @@ rule "terraform_required_variables" {
 				},
 			},
 		},
+		{
+			Name: "module with required variables that do not match the variable contracts.",
+			Content: `
+variable "cloud_creds" {
+  sensitive = true
+  type      = string
+}
+
+variable "module_tmpl" {
+  type = string
+}
+
+variable "module_info" {
+  type    = string
+  default = null
+}
+`,
+			Config: `
+rule "terraform_required_variables" {
+  enabled = true
+
+  variable "module_info" {
+    type                = "object({ name = string })"
+    nullable            = false
+    forbid_default      = true
+    require_description = true
+  }
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `module_info` must have `type = object({ name = string })` attribute defined",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 12, Column: 3},
+						End:      hcl.Pos{Line: 12, Column: 19},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `module_info` is missing the `nullable` attribute",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 1},
+						End:      hcl.Pos{Line: 11, Column: 23},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `module_info` must not have the `default` attribute",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 13, Column: 3},
+						End:      hcl.Pos{Line: 13, Column: 17},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `module_info` is missing the `description` attribute",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 1},
+						End:      hcl.Pos{Line: 11, Column: 23},
+					},
+				},
+			},
+		},
+		{
+			Name: "module with required variables that match the variable contracts.",
+			Content: `
+variable "cloud_creds" {
+  type = string
+}
+
+variable "module_tmpl" {
+  description = ""
+  type        = string
+}
+
+variable "module_info" {
+  description = "Information of the module."
+  type = object({
+    name = string
+    tags = optional(map(string), {})
+  })
+  nullable = false
+}
+`,
+			Config: `
+rule "terraform_required_variables" {
+  enabled = true
+
+  variable "cloud_creds" {
+    sensitive = false
+  }
+
+  variable "module_tmpl" {
+    type                = "string"
+    nullable            = true
+    require_description = true
+  }
+
+  variable "module_info" {
+    type                = "object({ tags = optional(map(string)), name = string })"
+    nullable            = false
+    forbid_default      = true
+    require_description = true
+  }
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `module_tmpl` must have a non-empty `description` attribute",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 19},
+					},
+				},
+			},
+		},
+		{
+			Name: "module with required variables that do not match the sensitive and nullable values of the variable contracts.",
+			Content: `
+variable "cloud_creds" {
+  type      = string
+  sensitive = true
+  nullable  = true
+}
+
+variable "module_tmpl" {
+  type = string
+}
+
+variable "module_info" {
+  type = string
+}
+`,
+			Config: `
+rule "terraform_required_variables" {
+  enabled = true
+
+  variable "cloud_creds" {
+    sensitive = false
+    nullable  = false
+  }
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `cloud_creds` must have `sensitive = false` attribute defined",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 19},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `cloud_creds` must have `nullable = false` attribute defined",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 3},
+						End:      hcl.Pos{Line: 5, Column: 19},
+					},
+				},
+			},
+		},
+		{
+			Name: "root module with the variables of the `root_module` preset.",
+			Content: `
+variable "cloud_creds" {
+  sensitive = true
+  type      = string
+}
+`,
+			Config: `
+rule "terraform_required_variables" {
+  enabled = true
+  preset  = "root_module"
+}
+`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "module with the built-in required variables disabled.",
+			Content: `
+variable "cloud_creds" {
+  type = string
+}
+
+variable "my_variable" {
+  type = string
+}
+`,
+			Config: `
+rule "terraform_required_variables" {
+  enabled       = true
+  preset        = "none"
+  required_vars = ["my_variable", "my_other_variable", "my_variable"]
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "required variable(s) not declared: my_other_variable",
+					Range: hcl.Range{
+						Start: hcl.Pos{Line: 1, Column: 1},
+						End:   hcl.Pos{Line: 1, Column: 1},
+					},
+				},
+			},
+		},
+		{
+			Name: "module with a required variable listed in both `required_vars` and the preset.",
+			Content: `
+variable "module_info" {
+  type = string
+}
+`,
+			Config: `
+rule "terraform_required_variables" {
+  enabled       = true
+  required_vars = ["cloud_creds"]
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "required variable(s) not declared: cloud_creds, module_tmpl",
+					Range: hcl.Range{
+						Start: hcl.Pos{Line: 1, Column: 1},
+						End:   hcl.Pos{Line: 1, Column: 1},
+					},
+				},
+			},
+		},
+		{
+			Name: "module with object variables that do not match the shapes of the variable contracts.",
+			Content: `
+variable "cloud_creds" {
+  sensitive = true
+  type      = string
+}
+
+variable "module_tmpl" {
+  type = object({
+    name = string
+  })
+}
+
+variable "module_info" {
+  type = object({
+    name    = number
+    owner   = string
+    env     = string
+    network = object({
+      vpc_id = string
+      extra  = bool
+    })
+  })
+}
+`,
+			Config: `
+rule "terraform_required_variables" {
+  enabled = true
+
+  variable "module_tmpl" {
+    type = "object({ name = string, version = string })"
+  }
+
+  variable "module_info" {
+    type = "object({ name = string, env = optional(string), team = string, network = object({ vpc_id = string, subnet_ids = list(string) }) })"
+  }
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `module_tmpl` is missing attribute `module_tmpl.version`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 10},
+						End:      hcl.Pos{Line: 10, Column: 5},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `module_info` attribute `module_info.name` must have type `string`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 15, Column: 15},
+						End:      hcl.Pos{Line: 15, Column: 21},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `module_info` has unexpected attribute `module_info.owner`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 16, Column: 5},
+						End:      hcl.Pos{Line: 16, Column: 21},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `module_info` attribute `module_info.env` must have type `optional(string)`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 17, Column: 15},
+						End:      hcl.Pos{Line: 17, Column: 21},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `module_info` has unexpected attribute `module_info.network.extra`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 20, Column: 7},
+						End:      hcl.Pos{Line: 20, Column: 20},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `module_info` is missing attribute `module_info.network.subnet_ids`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 18, Column: 15},
+						End:      hcl.Pos{Line: 21, Column: 7},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `module_info` is missing attribute `module_info.team`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 14, Column: 10},
+						End:      hcl.Pos{Line: 22, Column: 5},
+					},
+				},
+			},
+		},
+		{
+			Name: "module with object variables declaring quoted attributes.",
+			Content: `
+variable "cloud_creds" {
+  sensitive = true
+  type      = string
+}
+
+variable "module_tmpl" {
+  type = string
+}
+
+variable "module_info" {
+  type = object({
+    "name"  = string
+    "owner" = string
+  })
+}
+`,
+			Config: `
+rule "terraform_required_variables" {
+  enabled = true
+
+  variable "module_info" {
+    type = "object({ name = string })"
+  }
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `module_info` has unexpected attribute `module_info.owner`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 14, Column: 5},
+						End:      hcl.Pos{Line: 14, Column: 21},
+					},
+				},
+			},
+		},
+		{
+			Name: "module with object variables that match the shapes of the variable contracts.",
+			Content: `
+variable "cloud_creds" {
+  sensitive = true
+  type      = string
+}
+
+variable "module_tmpl" {
+  type = string
+}
+
+variable "module_info" {
+  type = object({
+    network = object({
+      subnet_ids = list(string)
+    })
+    name = string
+    env  = optional(string, "dev")
+  })
+}
+`,
+			Config: `
+rule "terraform_required_variables" {
+  enabled = true
+
+  variable "module_info" {
+    type = "object({ name = string, env = optional(string), network = object({ subnet_ids = list(string) }) })"
+  }
+}
+`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "module with required variables that are not referenced from the expected blocks.",
+			Content: `
+variable "cloud_creds" {
+  sensitive = true
+  type      = string
+}
+
+variable "module_tmpl" {
+  type = string
+}
+
+variable "module_info" {
+  type = string
+
+  validation {
+    condition     = var.module_info != "" && var.module_tmpl != ""
+    error_message = "must not be empty"
+  }
+}
+
+locals {
+  creds = var.cloud_creds
+  name  = "${var.module_info}-name"
+}
+
+provider "aws" {
+  region = local.creds
+}
+`,
+			Config: `
+rule "terraform_required_variables" {
+  enabled     = true
+  check_usage = true
+
+  variable "cloud_creds" {
+    used_in = ["provider", "module"]
+  }
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `cloud_creds` is not referenced from any `provider` or `module` block",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 23},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `module_tmpl` is declared but not referenced",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 1},
+						End:      hcl.Pos{Line: 7, Column: 23},
+					},
+				},
+			},
+		},
+		{
+			Name: "module with required variables that are referenced from the expected blocks.",
+			Content: `
+variable "cloud_creds" {
+  sensitive = true
+  type      = string
+}
+
+variable "module_tmpl" {
+  type = string
+}
+
+variable "module_info" {
+  type = string
+}
+
+variable "unused" {
+  type = string
+}
+
+provider "aws" {
+  access_key = var.cloud_creds
+}
+
+resource "aws_instance" "this" {
+  dynamic "tag" {
+    for_each = var.module_info
+    content {
+      value = var.module_tmpl
+    }
+  }
+}
+`,
+			Config: `
+rule "terraform_required_variables" {
+  enabled     = true
+  check_usage = true
+
+  variable "cloud_creds" {
+    used_in = ["provider"]
+  }
+}
+`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "module with default values on sensitive and credential variables.",
+			Content: `
+variable "cloud_creds" {
+  type      = string
+  sensitive = !false
+  default   = "secret"
+}
+
+variable "module_tmpl" {
+  type = string
+}
+
+variable "module_info" {
+  type = string
+}
+
+variable "db_password" {
+  type      = string
+  sensitive = true
+  default   = "hunter2"
+}
+
+variable "api_token" {
+  type    = string
+  default = "abc"
+}
+
+variable "region" {
+  type      = string
+  sensitive = false
+  default   = "us-east-1"
+}
+`,
+			Config: `
+rule "terraform_required_variables" {
+  enabled         = true
+  credential_vars = ["api_token"]
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `cloud_creds` must have a literal `sensitive` value",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 21},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `cloud_creds` holds sensitive values and must not have the `default` attribute",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 3},
+						End:      hcl.Pos{Line: 5, Column: 23},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `db_password` holds sensitive values and must not have the `default` attribute",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 19, Column: 3},
+						End:      hcl.Pos{Line: 19, Column: 24},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `api_token` holds sensitive values and must not have the `default` attribute",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 24, Column: 3},
+						End:      hcl.Pos{Line: 24, Column: 18},
+					},
+				},
+			},
+		},
+		{
+			Name: "module with a default value on a sensitive variable whose contract forbids a default.",
+			Content: `
+variable "cloud_creds" {
+  type      = string
+  sensitive = true
+  default   = "secret"
+}
+
+variable "module_tmpl" {
+  type = string
+}
+
+variable "module_info" {
+  type = string
+}
+`,
+			Config: `
+rule "terraform_required_variables" {
+  enabled = true
+
+  variable "cloud_creds" {
+    forbid_default = true
+  }
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `cloud_creds` must not have the `default` attribute",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 3},
+						End:      hcl.Pos{Line: 5, Column: 23},
+					},
+				},
+			},
+		},
 	}
 
 	rule := NewTerraformRequiredVariables()
@@ -247,3 +888,202 @@ rule "terraform_required_variables" {
   enabled = true
 }
 `
+
+func Test_TerraformRequiredVariables_VariablesFile(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+		Fixed    map[string]string
+	}{
+		{
+			Name: "missing required variables are appended to `variables.tf`.",
+			Files: map[string]string{
+				"main.tf": `
+resource "null_resource" "this" {}
+`,
+				"variables.tf": `variable "cloud_creds" {
+  type      = string
+  sensitive = true
+}
+`,
+				".tflint.hcl": testTerraformRequiredVariablesConfig,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "required variable(s) not declared: module_info, module_tmpl",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 1},
+					},
+				},
+			},
+			Fixed: map[string]string{
+				"variables.tf": `variable "cloud_creds" {
+  type      = string
+  sensitive = true
+}
+
+variable "module_info" {
+  description = "Information of the module."
+}
+
+variable "module_tmpl" {
+  description = "Template of the module."
+}
+`,
+			},
+		},
+		{
+			Name: "missing required variables of the default preset are appended without type.",
+			Files: map[string]string{
+				"variables.tf": `# Variables of the module
+`,
+				".tflint.hcl": testTerraformRequiredVariablesConfig,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "required variable(s) not declared: cloud_creds, module_info, module_tmpl",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 1},
+					},
+				},
+			},
+			Fixed: map[string]string{
+				"variables.tf": `# Variables of the module
+
+variable "cloud_creds" {
+  description = "Credentials of the cloud provider."
+  sensitive   = true
+}
+
+variable "module_info" {
+  description = "Information of the module."
+}
+
+variable "module_tmpl" {
+  description = "Template of the module."
+}
+`,
+			},
+		},
+		{
+			Name: "missing required variables are appended to the configured file according to their contract.",
+			Files: map[string]string{
+				"inputs.tf": `variable "other" {}`,
+				".tflint.hcl": `
+rule "terraform_required_variables" {
+  enabled        = true
+  preset         = "none"
+  required_vars  = ["db_password"]
+  variables_file = "inputs.tf"
+
+  variable "db_password" {
+    type        = "string"
+    sensitive   = true
+    nullable    = false
+    description = "Password of the \"main\" database."
+  }
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "required variable(s) not declared: db_password",
+					Range: hcl.Range{
+						Filename: "inputs.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 1},
+					},
+				},
+			},
+			Fixed: map[string]string{
+				"inputs.tf": `variable "other" {}
+
+variable "db_password" {
+  description = "Password of the \"main\" database."
+  type        = string
+  sensitive   = true
+  nullable    = false
+}
+`,
+			},
+		},
+		{
+			Name: "required variables referenced from files in the JSON syntax.",
+			Files: map[string]string{
+				"variables.tf": `variable "cloud_creds" {
+  type      = string
+  sensitive = true
+}
+
+variable "module_info" {
+  type = string
+}
+
+variable "module_tmpl" {
+  type = string
+}
+`,
+				"main.tf.json": `{
+  "provider": {
+    "aws": {
+      "access_key": "${var.cloud_creds}"
+    }
+  },
+  "resource": {
+    "null_resource": {
+      "this": {
+        "triggers": {
+          "info": "${var.module_info}"
+        }
+      }
+    }
+  }
+}`,
+				".tflint.hcl": `
+rule "terraform_required_variables" {
+  enabled     = true
+  check_usage = true
+
+  variable "cloud_creds" {
+    used_in = ["provider"]
+  }
+}
+`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVariables(),
+					Message: "variable `module_tmpl` is declared but not referenced",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 10, Column: 1},
+						End:      hcl.Pos{Line: 10, Column: 23},
+					},
+				},
+			},
+			Fixed: map[string]string{},
+		},
+	}
+
+	rule := NewTerraformRequiredVariables()
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, test.Files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, test.Expected, runner.Issues)
+			helper.AssertChanges(t, test.Fixed, runner.Changes())
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// TerraformSensitiveOutputs checks whether outputs exposing sensitive variables are marked as sensitive
+type TerraformSensitiveOutputs struct {
+	tflint.DefaultRule
+}
+
+type terraformSensitiveOutputsConfig struct {
+	SensitiveVars []string `hclext:"sensitive_vars,optional"`
+}
+
+// NewTerraformSensitiveOutputs returns a new rule
+func NewTerraformSensitiveOutputs() *TerraformSensitiveOutputs {
+	return &TerraformSensitiveOutputs{}
+}
+
+// Name returns the rule name
+func (r *TerraformSensitiveOutputs) Name() string {
+	return "terraform_sensitive_outputs"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformSensitiveOutputs) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformSensitiveOutputs) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *TerraformSensitiveOutputs) Link() string {
+	return "https://github.com/styumyum/tflint-ruleset-yumyum/docs/rules/terraform_sensitive_outputs.md"
+}
+
+// Check checks whether outputs referencing sensitive variables, directly or through locals,
+// have `sensitive = true` defined
+func (r *TerraformSensitiveOutputs) Check(runner tflint.Runner) error {
+	config := &terraformSensitiveOutputsConfig{}
+
+	if err := runner.DecodeRuleConfig(r.Name(), config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "sensitive"}},
+				},
+			},
+			{
+				Type: "locals",
+				Body: &hclext.BodySchema{Mode: hclext.SchemaJustAttributesMode},
+			},
+			{
+				Type:       "output",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{
+						{Name: "value"},
+						{Name: "sensitive"},
+					},
+				},
+			},
+		},
+	}, &tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return err
+	}
+
+	graph := &sensitiveReferenceGraph{
+		sensitiveVars: make(map[string]bool),
+		locals:        make(map[string][]sensitiveReference),
+		paths:         make(map[string][]string),
+	}
+	for _, name := range config.SensitiveVars {
+		graph.sensitiveVars[name] = true
+	}
+
+	var outputs []*hclext.Block
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "variable":
+			if attr, exists := block.Body.Attributes["sensitive"]; exists {
+				if sensitive, ok := literalBool(attr.Expr); ok && sensitive {
+					graph.sensitiveVars[block.Labels[0]] = true
+				}
+			}
+		case "locals":
+			for name, attr := range block.Body.Attributes {
+				graph.locals[name] = sensitiveReferences(attr.Expr)
+			}
+		case "output":
+			outputs = append(outputs, block)
+		}
+	}
+
+	graph.resolve()
+
+	for _, output := range outputs {
+		valueAttr, exists := output.Body.Attributes["value"]
+		if !exists {
+			continue
+		}
+
+		if attr, exists := output.Body.Attributes["sensitive"]; exists {
+			if sensitive, ok := literalBool(attr.Expr); !ok || sensitive {
+				continue
+			}
+		}
+
+		path := graph.sensitivePath(sensitiveReferences(valueAttr.Expr))
+		if path == nil {
+			continue
+		}
+
+		message := fmt.Sprintf(
+			"output `%s` must have `sensitive = true` attribute defined as it references sensitive variable `%s`",
+			output.Labels[0],
+			path[len(path)-1],
+		)
+		if len(path) > 1 {
+			locals := make([]string, len(path)-1)
+			for i, name := range path[:len(path)-1] {
+				locals[i] = fmt.Sprintf("`local.%s`", name)
+			}
+			message += fmt.Sprintf(" through %s", strings.Join(locals, ", "))
+		}
+
+		if err := runner.EmitIssue(r, message, valueAttr.Range); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sensitiveReference is a reference to a variable or a local value.
+type sensitiveReference struct {
+	Local bool
+	Name  string
+}
+
+// sensitiveReferenceGraph resolves whether references lead to sensitive variables through locals.
+type sensitiveReferenceGraph struct {
+	sensitiveVars map[string]bool
+	locals        map[string][]sensitiveReference
+
+	// paths holds the sensitive path of each local value that leads to a sensitive variable.
+	paths map[string][]string
+}
+
+// resolve computes the sensitive paths of the local values until no more local value
+// becomes sensitive, so that cyclic references terminate.
+func (g *sensitiveReferenceGraph) resolve() {
+	names := make([]string, 0, len(g.locals))
+	for name := range g.locals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for changed := true; changed; {
+		changed = false
+		for _, name := range names {
+			if g.paths[name] != nil {
+				continue
+			}
+			if path := g.sensitivePath(g.locals[name]); path != nil {
+				g.paths[name] = path
+				changed = true
+			}
+		}
+	}
+}
+
+// sensitivePath returns the names of the locals followed by the name of the first sensitive
+// variable reached from the references, or nil if none is reached.
+func (g *sensitiveReferenceGraph) sensitivePath(refs []sensitiveReference) []string {
+	for _, ref := range refs {
+		if !ref.Local {
+			if g.sensitiveVars[ref.Name] {
+				return []string{ref.Name}
+			}
+			continue
+		}
+
+		if path := g.paths[ref.Name]; path != nil {
+			return append([]string{ref.Name}, path...)
+		}
+	}
+
+	return nil
+}
+
+// sensitiveReferences returns the variables and locals referenced by the expression, in order.
+// References wrapped in `nonsensitive()` are explicitly declassified and skipped.
+func sensitiveReferences(expr hcl.Expression) []sensitiveReference {
+	var declassified []hcl.Range
+	if syntaxExpr, ok := expr.(hclsyntax.Expression); ok {
+		hclsyntax.VisitAll(syntaxExpr, func(node hclsyntax.Node) hcl.Diagnostics {
+			if call, ok := node.(*hclsyntax.FunctionCallExpr); ok && call.Name == "nonsensitive" {
+				declassified = append(declassified, call.Range())
+			}
+			return nil
+		})
+	}
+
+	var refs []sensitiveReference
+	for _, traversal := range expr.Variables() {
+		if len(traversal) < 2 {
+			continue
+		}
+
+		step, ok := traversal[1].(hcl.TraverseAttr)
+		if !ok {
+			continue
+		}
+
+		var local bool
+		switch traversal.RootName() {
+		case "var":
+		case "local":
+			local = true
+		default:
+			continue
+		}
+
+		if rangeWithinAny(traversal.SourceRange(), declassified) {
+			continue
+		}
+
+		refs = append(refs, sensitiveReference{Local: local, Name: step.Name})
+	}
+
+	return refs
+}
+
+// rangeWithinAny returns whether the range is contained in any of the given ranges.
+func rangeWithinAny(rng hcl.Range, ranges []hcl.Range) bool {
+	for _, outer := range ranges {
+		if outer.ContainsOffset(rng.Start.Byte) && rng.End.Byte <= outer.End.Byte {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformSensitiveOutputs(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "output referencing a sensitive variable without sensitive attribute.",
+			Content: `
+variable "cloud_creds" {
+  type      = object({ secret = string })
+  sensitive = true
+}
+
+output "creds" {
+  value = var.cloud_creds.secret
+}
+`,
+			Config: testTerraformSensitiveOutputsConfig,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformSensitiveOutputs(),
+					Message: "output `creds` must have `sensitive = true` attribute defined as it references sensitive variable `cloud_creds`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 3},
+						End:      hcl.Pos{Line: 8, Column: 33},
+					},
+				},
+			},
+		},
+		{
+			Name: "output referencing a sensitive variable through locals with `sensitive = false`.",
+			Content: `
+variable "cloud_creds" {
+  type      = string
+  sensitive = true
+}
+
+locals {
+  creds  = local.header
+  header = "Bearer ${var.cloud_creds}"
+}
+
+output "creds" {
+  value     = { header = local.creds }
+  sensitive = false
+}
+`,
+			Config: testTerraformSensitiveOutputsConfig,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformSensitiveOutputs(),
+					Message: "output `creds` must have `sensitive = true` attribute defined as it references sensitive variable `cloud_creds` through `local.creds`, `local.header`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 13, Column: 3},
+						End:      hcl.Pos{Line: 13, Column: 39},
+					},
+				},
+			},
+		},
+		{
+			Name: "output referencing a sensitive variable through cyclic locals.",
+			Content: `
+variable "cloud_creds" {
+  type      = string
+  sensitive = true
+}
+
+locals {
+  a = [local.b, var.cloud_creds]
+  b = local.a
+}
+
+output "b" {
+  value = local.b
+}
+`,
+			Config: testTerraformSensitiveOutputsConfig,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformSensitiveOutputs(),
+					Message: "output `b` must have `sensitive = true` attribute defined as it references sensitive variable `cloud_creds` through `local.b`, `local.a`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 13, Column: 3},
+						End:      hcl.Pos{Line: 13, Column: 18},
+					},
+				},
+			},
+		},
+		{
+			Name: "outputs that are sensitive, declassified or not referencing sensitive variables.",
+			Content: `
+variable "cloud_creds" {
+  type      = string
+  sensitive = true
+}
+
+variable "region" {
+  type = string
+}
+
+locals {
+  creds = var.cloud_creds
+}
+
+output "creds" {
+  value     = local.creds
+  sensitive = true
+}
+
+output "creds_length" {
+  value = nonsensitive(length(local.creds))
+}
+
+output "region" {
+  value = var.region
+}
+`,
+			Config:   testTerraformSensitiveOutputsConfig,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "output referencing a variable listed in `sensitive_vars`.",
+			Content: `
+variable "api_token" {
+  type = string
+}
+
+output "token" {
+  value = var.api_token
+}
+`,
+			Config: `
+rule "terraform_sensitive_outputs" {
+  enabled        = true
+  sensitive_vars = ["api_token"]
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformSensitiveOutputs(),
+					Message: "output `token` must have `sensitive = true` attribute defined as it references sensitive variable `api_token`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 24},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewTerraformSensitiveOutputs()
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{
+				"main.tf":     test.Content,
+				".tflint.hcl": test.Config,
+			})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, test.Expected, runner.Issues)
+		})
+	}
+}
+
+const testTerraformSensitiveOutputsConfig = `
+rule "terraform_sensitive_outputs" {
+  enabled = true
+}
+`